@@ -0,0 +1,348 @@
+package teamspeak
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Separates the channel path from the file path in backup names. Channel names never produce this segment as
+// ChannelPath escapes "%" in them.
+const backupFilesSegment = "%files"
+
+// Stores the files downloaded by BackupChannelFiles, name is "<channel path>/%files/<file path>" (see BackupName)
+type BackupWriter interface {
+	WriteFile(name string, size int64, modTime time.Time, reader io.Reader) error
+}
+
+// Reads back the files stored by a BackupWriter for RestoreChannelFiles
+type BackupReader interface {
+	Walk(fn func(name string, size int64, reader io.Reader) error) error
+}
+
+// The outcome of BackupChannelFiles or RestoreChannelFiles. Files lists the backup names transferred, Skipped
+// the backup names (or, for channels whose file area could not be read, the channel paths) left out.
+type BackupReport struct {
+	Files   []string
+	Bytes   uint64
+	Skipped []string
+}
+
+// Escapes a channel name for use as a single channel path segment
+func escapeChannelName(name string) string {
+	if name == "." || name == ".." {
+		return strings.Replace(name, ".", "%2E", -1)
+	}
+
+	return strings.NewReplacer("%", "%25", "/", "%2F").Replace(name)
+}
+
+// Builds the path of the channel from the names of it and its parents, e.g. "Lobby/Games". A "/" in a channel
+// name is escaped as %2F (and "%" as %25) so it does not introduce another level.
+func ChannelPath(channels []*Channel, cid uint) (string, error) {
+	byCid := make(map[uint]*Channel, len(channels))
+	for _, channel := range channels {
+		byCid[channel.Cid] = channel
+	}
+
+	names := make([]string, 0)
+	for cid != 0 {
+		channel, found := byCid[cid]
+		if !found {
+			return "", errors.New(fmt.Sprintf("Channel %d not found", cid))
+		}
+		if len(names) > len(channels) {
+			return "", errors.New(fmt.Sprintf("Channel %d has a cyclic parent chain", channel.Cid))
+		}
+
+		names = append([]string{escapeChannelName(channel.Name)}, names...)
+		cid = channel.Pid
+	}
+
+	return strings.Join(names, "/"), nil
+}
+
+// Combines a channel path and the path of a file in its file area into the name used in backups, e.g.
+// "Lobby/Games/%files/maps/dust2.bsp". Channels stay nested directories, the %files segment marks where the
+// channel's file area starts.
+func BackupName(channelPath, filePath string) string {
+	return channelPath + "/" + backupFilesSegment + path.Join("/", filePath)
+}
+
+// Splits a backup name into the channel path and the file path within the channel's file area
+func SplitBackupName(name string) (string, string, error) {
+	segments := strings.Split(strings.TrimPrefix(filepath.ToSlash(name), "/"), "/")
+
+	for i, segment := range segments {
+		if segment == backupFilesSegment && i > 0 && i < len(segments)-1 {
+			return strings.Join(segments[:i], "/"), "/" + strings.Join(segments[i+1:], "/"), nil
+		}
+	}
+
+	return "", "", errors.New(fmt.Sprintf("Backup name %v has no %v segment", name, backupFilesSegment))
+}
+
+// Downloads the files of every channel on the selected virtual server into the backup, keyed by channel path.
+// Channels and files the server refuses to hand out (password protected or lacking permissions) are recorded in
+// the report's Skipped list and the backup carries on, any other error ends it.
+func (ts3 *Connection) BackupChannelFiles(backup BackupWriter) (*BackupReport, error) {
+	report := &BackupReport{Files: make([]string, 0), Skipped: make([]string, 0)}
+
+	channels, err := ts3.ChannelList()
+	if err != nil {
+		return report, err
+	}
+
+	for _, channel := range channels {
+		channelPath, err := ChannelPath(channels, channel.Cid)
+		if err != nil {
+			return report, err
+		}
+
+		err = ts3.backupDirectory(backup, report, channel.Cid, channelPath, "/")
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// Downloads the files in the directory of the channel's file area, descending into subdirectories
+func (ts3 *Connection) backupDirectory(backup BackupWriter, report *BackupReport, cid uint, channelPath, dir string) error {
+	entries, err := ts3.FtGetFileList(cid, "", dir)
+	if isError(err, ErrorChannelInvalidPassword, ErrorInsufficientClientPermissions) {
+		if dir == "/" {
+			report.Skipped = append(report.Skipped, channelPath)
+		} else {
+			report.Skipped = append(report.Skipped, BackupName(channelPath, dir))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		filePath := path.Join(dir, entry.Name)
+
+		if entry.IsDir() {
+			err = ts3.backupDirectory(backup, report, cid, channelPath, filePath+"/")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := BackupName(channelPath, filePath)
+
+		// Besides refusals, the file may have been removed since it was listed
+		stream, err := ts3.Download(cid, filePath)
+		if isError(err, ErrorChannelInvalidPassword, ErrorInsufficientClientPermissions, ErrorFileInvalidPath) {
+			report.Skipped = append(report.Skipped, name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		counted := &progressReader{reader: stream}
+		err = backup.WriteFile(name, int64(entry.Size), entry.ModifiedAt(), counted)
+		stream.Close()
+		if err != nil {
+			return err
+		}
+
+		report.Files = append(report.Files, name)
+		report.Bytes += counted.transferred
+	}
+
+	return nil
+}
+
+// Uploads the files in the backup onto the channels of the selected virtual server, matching channels by path
+// (names) rather than cid. Files of channels that do not exist on the server are skipped.
+func (ts3 *Connection) RestoreChannelFiles(backup BackupReader) (*BackupReport, error) {
+	report := &BackupReport{Files: make([]string, 0), Skipped: make([]string, 0)}
+
+	channels, err := ts3.ChannelList()
+	if err != nil {
+		return report, err
+	}
+
+	cids := make(map[string]uint, len(channels))
+	for _, channel := range channels {
+		channelPath, err := ChannelPath(channels, channel.Cid)
+		if err != nil {
+			return report, err
+		}
+		cids[channelPath] = channel.Cid
+	}
+
+	created := make(map[string]bool)
+	err = backup.Walk(func(name string, size int64, reader io.Reader) error {
+		channelPath, filePath, err := SplitBackupName(name)
+		if err != nil {
+			return err
+		}
+
+		cid, found := cids[channelPath]
+		if !found {
+			report.Skipped = append(report.Skipped, name)
+			return nil
+		}
+
+		err = ts3.createParentDirs(cid, filePath, created)
+		if err != nil {
+			return err
+		}
+
+		err = ts3.Upload(cid, filePath, reader, TransferOptions{Overwrite: true})
+		if err != nil {
+			return err
+		}
+
+		report.Files = append(report.Files, name)
+		report.Bytes += uint64(size)
+		return nil
+	})
+
+	return report, err
+}
+
+// Creates the parent directories of the file in the channel's file area, shallowest first. Directories that
+// already exist are fine, created remembers the ones handled so far.
+func (ts3 *Connection) createParentDirs(cid uint, filePath string, created map[string]bool) error {
+	dirs := make([]string, 0)
+	for dir := path.Dir(filePath); dir != "/" && dir != "."; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+
+	for _, dir := range dirs {
+		key := fmt.Sprintf("%d:%v", cid, dir)
+		if created[key] {
+			continue
+		}
+
+		err := ts3.FtCreateDir(cid, "", dir)
+		if err != nil && !isError(err, ErrorFileAlreadyExists) {
+			return err
+		}
+
+		created[key] = true
+	}
+
+	return nil
+}
+
+// A backup stored as plain files below a local directory
+type DirBackup string
+
+func (dir DirBackup) WriteFile(name string, size int64, modTime time.Time, reader io.Reader) error {
+	target := filepath.Join(string(dir), filepath.FromSlash(name))
+
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+
+	// A reader ending short of size (e.g. a download cut off by the server) fails rather than leaving a short file
+	_, err = io.CopyN(file, reader, size)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(target)
+		return err
+	}
+
+	return os.Chtimes(target, modTime, modTime)
+}
+
+func (dir DirBackup) Walk(fn func(name string, size int64, reader io.Reader) error) error {
+	return filepath.Walk(string(dir), func(target string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(string(dir), target)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(target)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		return fn(filepath.ToSlash(name), info.Size(), file)
+	})
+}
+
+// A backup written as a tar archive
+type TarBackupWriter struct {
+	writer *tar.Writer
+}
+
+func NewTarBackupWriter(writer io.Writer) *TarBackupWriter {
+	return &TarBackupWriter{writer: tar.NewWriter(writer)}
+}
+
+func (backup *TarBackupWriter) WriteFile(name string, size int64, modTime time.Time, reader io.Reader) error {
+	err := backup.writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size, ModTime: modTime, Typeflag: tar.TypeReg})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(backup.writer, reader, size)
+	return err
+}
+
+// Finishes the archive, the underlying writer is left open
+func (backup *TarBackupWriter) Close() error {
+	return backup.writer.Close()
+}
+
+// A backup read from a tar archive
+type TarBackupReader struct {
+	reader io.Reader
+}
+
+func NewTarBackupReader(reader io.Reader) *TarBackupReader {
+	return &TarBackupReader{reader: reader}
+}
+
+func (backup *TarBackupReader) Walk(fn func(name string, size int64, reader io.Reader) error) error {
+	archive := tar.NewReader(backup.reader)
+
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		err = fn(header.Name, header.Size, archive)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package teamspeak
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackupName(t *testing.T) {
+	channels := []*Channel{
+		&Channel{Cid: 1, Name: "Lobby"},
+		&Channel{Cid: 2, Pid: 1, Name: "Games"},
+		&Channel{Cid: 3, Pid: 2, Name: "AC/DC Fans"},
+		&Channel{Cid: 4, Name: "%files"},
+	}
+
+	channelPath, err := ChannelPath(channels, 3)
+	if err != nil {
+		t.Errorf("ChannelPath(3): Errored out with %v", err)
+	} else if channelPath != "Lobby/Games/AC%2FDC Fans" {
+		t.Errorf("ChannelPath(3): Returned %v", channelPath)
+	}
+
+	// A channel named like the separator is escaped so it cannot be mistaken for it
+	if channelPath, _ := ChannelPath(channels, 4); channelPath != "%25files" {
+		t.Errorf("ChannelPath(4): Returned %v", channelPath)
+	}
+
+	if _, err = ChannelPath(channels, 5); err == nil {
+		t.Errorf("ChannelPath(5): Should have thrown an error for an unknown channel")
+	}
+
+	name := BackupName(channelPath, "/docs/rules.txt")
+	if name != "Lobby/Games/AC%2FDC Fans/%files/docs/rules.txt" {
+		t.Errorf("BackupName(%v): Returned %v", channelPath, name)
+	}
+
+	// The channel path survives the round trip through a backup name
+	for _, channelPath := range []string{channelPath, "%25files", "Lobby"} {
+		name := BackupName(channelPath, "/docs/%files/rules.txt")
+		splitChannelPath, filePath, err := SplitBackupName(name)
+		if err != nil {
+			t.Errorf("SplitBackupName(%v): Errored out with %v", name, err)
+		} else if splitChannelPath != channelPath || filePath != "/docs/%files/rules.txt" {
+			t.Errorf("SplitBackupName(%v): Returned %v and %v", name, splitChannelPath, filePath)
+		}
+	}
+
+	if _, _, err = SplitBackupName("Lobby/rules.txt"); err == nil {
+		t.Errorf("SplitBackupName(Lobby/rules.txt): Should have thrown an error for a name without a separator")
+	}
+}
+
+// Writes the files to the backup and reads them back
+func backupRoundTrip(t *testing.T, writer BackupWriter, reader func() BackupReader) {
+	files := map[string]string{
+		BackupName("Lobby", "/rules.txt"):            "be nice",
+		BackupName("Lobby/Games", "/maps/dust2.bsp"): "map data",
+	}
+
+	for name, contents := range files {
+		err := writer.WriteFile(name, int64(len(contents)), time.Unix(1400000000, 0), strings.NewReader(contents))
+		if err != nil {
+			t.Errorf("WriteFile(%v): Errored out with %v", name, err)
+		}
+	}
+
+	read := make(map[string]string)
+	err := reader().Walk(func(name string, size int64, reader io.Reader) error {
+		data, err := io.ReadAll(reader)
+		read[name] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Errorf("Walk(): Errored out with %v", err)
+	}
+
+	if fmt.Sprint(read) != fmt.Sprint(files) {
+		t.Errorf("Walk(): Expected %v, received %v", files, read)
+	}
+}
+
+func TestDirBackup(t *testing.T) {
+	dir := DirBackup(t.TempDir())
+	backupRoundTrip(t, dir, func() BackupReader { return dir })
+
+	// A reader ending short of the size fails and leaves nothing behind
+	name := BackupName("Lobby", "/short.txt")
+	if err := dir.WriteFile(name, 8, time.Unix(1400000000, 0), strings.NewReader("map")); err == nil {
+		t.Errorf("WriteFile(%v): Should have thrown an error for a reader shorter than the size", name)
+	}
+	if _, err := os.Stat(filepath.Join(string(dir), filepath.FromSlash(name))); !os.IsNotExist(err) {
+		t.Errorf("WriteFile(%v): Left a partial file behind (%v)", name, err)
+	}
+}
+
+func TestTarBackup(t *testing.T) {
+	archive := &bytes.Buffer{}
+	writer := NewTarBackupWriter(archive)
+
+	backupRoundTrip(t, writer, func() BackupReader {
+		writer.Close()
+		return NewTarBackupReader(bytes.NewReader(archive.Bytes()))
+	})
+}
+
+func TestBackupChannelFiles(t *testing.T) {
+	port, _ := serveTransfer(t, "backupkey", []byte("map data"))
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=7 pid=0 channel_order=0 channel_name=Lobby total_clients=0 channel_needed_subscribe_power=0|cid=8 pid=0 channel_order=7 channel_name=Private total_clients=0 channel_needed_subscribe_power=0|cid=10 pid=0 channel_order=8 channel_name=Staff total_clients=0 channel_needed_subscribe_power=0|cid=9 pid=7 channel_order=0 channel_name=Games total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftgetfilelist cid=7 cpw= path=\\/", "error id=1281 msg=database\\sempty\\sresult\\sset"},
+		{"ftgetfilelist cid=8 cpw= path=\\/", "error id=781 msg=invalid\\spassword"},
+		{"ftgetfilelist cid=10 cpw= path=\\/", "error id=2568 msg=insufficient\\sclient\\spermissions"},
+		{"ftgetfilelist cid=9 cpw= path=\\/", "cid=9 path=\\/ name=maps size=0 datetime=1400000000 type=0\n\rerror id=0 msg=ok"},
+		{"ftgetfilelist cid=9 cpw= path=\\/maps\\/", "cid=9 path=\\/maps\\/ name=dust2.bsp size=8 datetime=1400000000 type=1|name=locked.bsp size=4 datetime=1400000000 type=1\n\rerror id=0 msg=ok"},
+		{"ftinitdownload clientftfid=1 name=\\/maps\\/dust2.bsp cid=9 cpw= seekpos=0", fmt.Sprintf("clientftfid=1 serverftfid=2 ftkey=backupkey port=%d size=8\n\rerror id=0 msg=ok", port)},
+		{"ftinitdownload clientftfid=2 name=\\/maps\\/locked.bsp cid=9 cpw= seekpos=0", "clientftfid=2 status=2051 msg=invalid\\sfile\\spath\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+	ts3.TransferHost = "127.0.0.1"
+
+	// The channels and the file the server refuses are skipped, the rest is backed up
+	dir := DirBackup(t.TempDir())
+	report, err := ts3.BackupChannelFiles(dir)
+	if err != nil {
+		t.Errorf("ts3.BackupChannelFiles(): Errored out with %v", err)
+		return
+	}
+
+	expected := &BackupReport{
+		Files:   []string{"Lobby/Games/%files/maps/dust2.bsp"},
+		Bytes:   8,
+		Skipped: []string{"Private", "Staff", "Lobby/Games/%files/maps/locked.bsp"},
+	}
+	if fmt.Sprint(report) != fmt.Sprint(expected) {
+		t.Errorf("ts3.BackupChannelFiles(): Expected report %v, received %v", expected, report)
+	}
+
+	read := make(map[string]string)
+	dir.Walk(func(name string, size int64, reader io.Reader) error {
+		data, err := io.ReadAll(reader)
+		read[name] = string(data)
+		return err
+	})
+	if read["Lobby/Games/%files/maps/dust2.bsp"] != "map data" || len(read) != 1 {
+		t.Errorf("ts3.BackupChannelFiles(): Unexpected backup contents %v", read)
+	}
+}
+
+func TestBackupChannelFilesError(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=7 pid=0 channel_order=0 channel_name=Lobby total_clients=0 channel_needed_subscribe_power=0|cid=9 pid=0 channel_order=7 channel_name=Games total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftgetfilelist cid=7 cpw= path=\\/", "error id=1024 msg=invalid\\sserverID"},
+	})
+	defer ts3.Close()
+
+	// Errors other than a refusal are not per channel, the backup stops rather than skipping every channel
+	report, err := ts3.BackupChannelFiles(DirBackup(t.TempDir()))
+	if !isError(err, 1024) {
+		t.Errorf("ts3.BackupChannelFiles(): Expected error 1024, received %v", err)
+	}
+	if len(report.Skipped) != 0 {
+		t.Errorf("ts3.BackupChannelFiles(): Unexpected report %v", report)
+	}
+}
+
+func TestBackupChannelFilesTruncated(t *testing.T) {
+	port, _ := serveTransfer(t, "shortkey", []byte("map"))
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=9 pid=0 channel_order=0 channel_name=Games total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftgetfilelist cid=9 cpw= path=\\/", "cid=9 path=\\/ name=dust2.bsp size=8 datetime=1400000000 type=1\n\rerror id=0 msg=ok"},
+		{"ftinitdownload clientftfid=1 name=\\/dust2.bsp cid=9 cpw= seekpos=0", fmt.Sprintf("clientftfid=1 serverftfid=2 ftkey=shortkey port=%d size=8\n\rerror id=0 msg=ok", port)},
+	})
+	defer ts3.Close()
+	ts3.TransferHost = "127.0.0.1"
+
+	// The transfer server sends 3 of the 8 bytes, the file must not be reported as backed up
+	dir := DirBackup(t.TempDir())
+	report, err := ts3.BackupChannelFiles(dir)
+	if err == nil {
+		t.Errorf("ts3.BackupChannelFiles(): Should have thrown an error for a truncated download")
+	}
+	if len(report.Files) != 0 || report.Bytes != 0 {
+		t.Errorf("ts3.BackupChannelFiles(): Unexpected report %v", report)
+	}
+}
+
+func TestRestoreChannelFiles(t *testing.T) {
+	port, received := serveTransfer(t, "restorekey", nil)
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=7 pid=0 channel_order=0 channel_name=Lobby total_clients=0 channel_needed_subscribe_power=0|cid=9 pid=7 channel_order=0 channel_name=Games total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftcreatedir cid=9 cpw= dirname=\\/maps", "error id=0 msg=ok"},
+		{"ftinitupload clientftfid=1 name=\\/maps\\/dust2.bsp cid=9 cpw= size=8 overwrite=1 resume=0", fmt.Sprintf("clientftfid=1 serverftfid=2 ftkey=restorekey port=%d seekpos=0\n\rerror id=0 msg=ok", port)},
+	})
+	defer ts3.Close()
+	ts3.TransferHost = "127.0.0.1"
+
+	archive := &bytes.Buffer{}
+	writer := NewTarBackupWriter(archive)
+	writer.WriteFile(BackupName("Lobby/Games", "/maps/dust2.bsp"), 8, time.Unix(1400000000, 0), strings.NewReader("map data"))
+	writer.WriteFile(BackupName("Gone", "/old.txt"), 3, time.Unix(1400000000, 0), strings.NewReader("old"))
+	writer.Close()
+
+	// The file lands in the channel with the same path (cid 9 rather than the original), unknown channels are skipped
+	report, err := ts3.RestoreChannelFiles(NewTarBackupReader(archive))
+	if err != nil {
+		t.Errorf("ts3.RestoreChannelFiles(): Errored out with %v", err)
+		return
+	}
+	if data := <-received; string(data) != "map data" {
+		t.Errorf("ts3.RestoreChannelFiles(): Expected the server to receive \"map data\", received %q", data)
+	}
+	if len(report.Files) != 1 || len(report.Skipped) != 1 || report.Bytes != 8 {
+		t.Errorf("ts3.RestoreChannelFiles(): Unexpected report %v", report)
+	}
+}
+
+func TestRestoreChannelFilesNested(t *testing.T) {
+	port, received := serveTransfer(t, "nestedkey", nil)
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=7 pid=0 channel_order=0 channel_name=Lobby total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftcreatedir cid=7 cpw= dirname=\\/maps", "error id=2050 msg=file\\salready\\sexists"},
+		{"ftcreatedir cid=7 cpw= dirname=\\/maps\\/de", "error id=0 msg=ok"},
+		{"ftinitupload clientftfid=1 name=\\/maps\\/de\\/dust2.bsp cid=7 cpw= size=8 overwrite=1 resume=0", fmt.Sprintf("clientftfid=1 serverftfid=2 ftkey=nestedkey port=%d seekpos=0\n\rerror id=0 msg=ok", port)},
+	})
+	defer ts3.Close()
+	ts3.TransferHost = "127.0.0.1"
+
+	archive := &bytes.Buffer{}
+	writer := NewTarBackupWriter(archive)
+	writer.WriteFile(BackupName("Lobby", "/maps/de/dust2.bsp"), 8, time.Unix(1400000000, 0), strings.NewReader("map data"))
+	writer.Close()
+
+	// Parents are created shallowest first, an already existing directory is not an error
+	report, err := ts3.RestoreChannelFiles(NewTarBackupReader(archive))
+	if err != nil {
+		t.Errorf("ts3.RestoreChannelFiles(): Errored out with %v", err)
+		return
+	}
+	if data := <-received; string(data) != "map data" {
+		t.Errorf("ts3.RestoreChannelFiles(): Expected the server to receive \"map data\", received %q", data)
+	}
+	if len(report.Files) != 1 || report.Bytes != 8 {
+		t.Errorf("ts3.RestoreChannelFiles(): Unexpected report %v", report)
+	}
+}
+
+func TestRestoreChannelFilesCreateDirError(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"channellist", "cid=7 pid=0 channel_order=0 channel_name=Lobby total_clients=0 channel_needed_subscribe_power=0\n\rerror id=0 msg=ok"},
+		{"ftcreatedir cid=7 cpw= dirname=\\/maps", "error id=2568 msg=insufficient\\sclient\\spermissions"},
+	})
+	defer ts3.Close()
+
+	archive := &bytes.Buffer{}
+	writer := NewTarBackupWriter(archive)
+	writer.WriteFile(BackupName("Lobby", "/maps/de/dust2.bsp"), 8, time.Unix(1400000000, 0), strings.NewReader("map data"))
+	writer.Close()
+
+	// Any other error creating a directory stops the restore before the upload
+	report, err := ts3.RestoreChannelFiles(NewTarBackupReader(archive))
+	if ts3Err, ok := err.(*Error); !ok || ts3Err.Id != 2568 {
+		t.Errorf("ts3.RestoreChannelFiles(): Expected error 2568, received %v", err)
+	}
+	if len(report.Files) != 0 {
+		t.Errorf("ts3.RestoreChannelFiles(): Unexpected report %v", report)
+	}
+}
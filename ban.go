@@ -0,0 +1,142 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Ban struct {
+	Id            uint   `sq:"banid"`
+	Ip            string `sq:"ip"`
+	Name          string `sq:"name"`
+	Uid           string `sq:"uid"`
+	MyTsId        string `sq:"mytsid"`
+	LastNickname  string `sq:"lastnickname"`
+	Created       int64  `sq:"created"`
+	Duration      uint   `sq:"duration"`
+	InvokerName   string `sq:"invokername"`
+	InvokerCldbid uint   `sq:"invokercldbid"`
+	InvokerUid    string `sq:"invokeruid"`
+	Reason        string `sq:"reason"`
+	Enforcements  uint   `sq:"enforcements"`
+}
+
+func NewBan(banStr string) (*Ban, error) {
+	ban := &Ban{}
+
+	err := decodeProperties(ban, banStr)
+	if err != nil {
+		return ban, err
+	}
+
+	return ban, nil
+}
+
+// When the ban was created
+func (ban *Ban) CreatedAt() time.Time {
+	return time.Unix(ban.Created, 0)
+}
+
+// Reports whether the ban never expires
+func (ban *Ban) Permanent() bool {
+	return ban.Duration == 0
+}
+
+// How much longer the ban is enforced as of now, zero for permanent bans
+func (ban *Ban) Remaining(now time.Time) time.Duration {
+	if ban.Permanent() {
+		return 0
+	}
+
+	remaining := ban.CreatedAt().Add(time.Duration(ban.Duration) * time.Second).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// Reads the bans of the selected virtual server, start and count page through the list when non zero
+func (ts3 *Connection) BanList(start, count uint) ([]*Ban, error) {
+	command := "banlist"
+	if start != 0 {
+		command += fmt.Sprintf(" start=%d", start)
+	}
+	if count != 0 {
+		command += fmt.Sprintf(" duration=%d", count)
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*Ban, 0), nil
+	}
+	if err != nil {
+		return make([]*Ban, 0), err
+	}
+
+	rawBans := splitEntries(response)
+	bans := make([]*Ban, len(rawBans))
+	for i, rawBan := range rawBans {
+		ban, err := NewBan(rawBan)
+		if err != nil {
+			return bans, err
+		}
+		bans[i] = ban
+	}
+
+	return bans, nil
+}
+
+// Adds a ban rule matching the ban's Ip (regex), Name (regex), Uid and/or MyTsId for Duration seconds (0 bans
+// permanently) with the ban's Reason. The id of the new ban is stored on ban.
+func (ts3 *Connection) BanAdd(ban *Ban) (uint, error) {
+	command := "banadd"
+	if len(ban.Ip) > 0 {
+		command += fmt.Sprintf(" ip=%v", Escape(ban.Ip))
+	}
+	if len(ban.Name) > 0 {
+		command += fmt.Sprintf(" name=%v", Escape(ban.Name))
+	}
+	if len(ban.Uid) > 0 {
+		command += fmt.Sprintf(" uid=%v", Escape(ban.Uid))
+	}
+	if len(ban.MyTsId) > 0 {
+		command += fmt.Sprintf(" mytsid=%v", Escape(ban.MyTsId))
+	}
+	if command == "banadd" {
+		return 0, errors.New("Ban needs at least one of Ip, Name, Uid or MyTsId")
+	}
+
+	if ban.Duration != 0 {
+		command += fmt.Sprintf(" time=%d", ban.Duration)
+	}
+	if len(ban.Reason) > 0 {
+		command += fmt.Sprintf(" banreason=%v", Escape(ban.Reason))
+	}
+
+	response, err := ts3.exec(command)
+	if err != nil {
+		return 0, err
+	}
+
+	created := struct {
+		Id uint `sq:"banid"`
+	}{}
+	err = decodeProperties(&created, response)
+	ban.Id = created.Id
+
+	return created.Id, err
+}
+
+// Deletes the ban
+func (ts3 *Connection) BanDel(banid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("bandel banid=%d", banid))
+	return err
+}
+
+// Deletes every ban on the selected virtual server
+func (ts3 *Connection) BanDelAll() error {
+	_, err := ts3.exec("bandelall")
+	return err
+}
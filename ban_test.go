@@ -0,0 +1,46 @@
+package teamspeak
+
+import (
+	"testing"
+	"time"
+)
+
+const validBanString = "banid=5 ip name=Troll.* uid mytsid lastnickname created=1400000000 duration=3600 invokername=Admin invokercldbid=2 invokeruid=abc= reason=Spamming\\slinks enforcements=3"
+
+func TestNewBan(t *testing.T) {
+	ban, err := NewBan(validBanString)
+	if err != nil {
+		t.Errorf("NewBan(\"%v\"): Errored out with %v", validBanString, err)
+		return
+	}
+
+	if ban.Id != 5 || ban.Ip != "" || ban.Name != "Troll.*" || ban.Created != 1400000000 || ban.Duration != 3600 || ban.InvokerCldbid != 2 || ban.Reason != "Spamming links" || ban.Enforcements != 3 {
+		t.Errorf("NewBan(\"%v\"): Parsed version %v does not match source input", validBanString, ban)
+	}
+
+	if remaining := ban.Remaining(time.Unix(1400000600, 0)); remaining != 50*time.Minute {
+		t.Errorf("ban.Remaining(): Expected 50m, received %v", remaining)
+	}
+	if remaining := ban.Remaining(time.Unix(1500000000, 0)); remaining != 0 {
+		t.Errorf("ban.Remaining(): Expected an expired ban, received %v", remaining)
+	}
+}
+
+func TestBanAdd(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"banadd uid=abc\\/def= time=600 banreason=Be\\snice", "banid=12\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	ban := &Ban{Uid: "abc/def=", Duration: 600, Reason: "Be nice"}
+	banid, err := ts3.BanAdd(ban)
+	if err != nil {
+		t.Errorf("ts3.BanAdd(%v): Errored out with %v", ban, err)
+	} else if banid != 12 || ban.Id != 12 {
+		t.Errorf("ts3.BanAdd(%v): Returned ban id %d", ban, banid)
+	}
+
+	if _, err = ts3.BanAdd(&Ban{Reason: "Nobody"}); err == nil {
+		t.Errorf("ts3.BanAdd(): Should have required a rule")
+	}
+}
@@ -0,0 +1,162 @@
+package teamspeak
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A virtual server taking part in a ban synchronization, Sid is selected before reading or adding bans (0 keeps
+// the server already selected on the connection)
+type BanSyncTarget struct {
+	Connection *Connection
+	Sid        uint
+}
+
+// A ban missing on a target, Duration holds the remaining time of the source ban
+type BanSyncAction struct {
+	Target *BanSyncTarget
+	Ban    *Ban
+}
+
+// The outcome of SyncBans
+type BanSyncReport struct {
+	// Every active ban rule across the targets
+	Bans []*Ban
+
+	// Bans missing on a target, with Applied set once they were added (never during a dry run)
+	Actions []*BanSyncAction
+	Applied bool
+}
+
+// Summarises the report one line per ban to add
+func (report *BanSyncReport) String() string {
+	lines := make([]string, 0, len(report.Actions)+1)
+
+	verb := "would add"
+	if report.Applied {
+		verb = "added"
+	}
+	lines = append(lines, fmt.Sprintf("%d bans across targets, %s %d", len(report.Bans), verb, len(report.Actions)))
+
+	for _, action := range report.Actions {
+		duration := "permanent"
+		if !action.Ban.Permanent() {
+			duration = (time.Duration(action.Ban.Duration) * time.Second).String()
+		}
+		lines = append(lines, fmt.Sprintf("sid %d: %v (%v) %v", action.Target.Sid, banKey(action.Ban), duration, action.Ban.Reason))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Identifies a ban by its rule rather than its per-server id
+func banKey(ban *Ban) string {
+	return fmt.Sprintf("ip=%v name=%v uid=%v mytsid=%v", ban.Ip, ban.Name, ban.Uid, ban.MyTsId)
+}
+
+// Reads the bans of every target and adds the bans missing on each one, preserving the reason and remaining
+// duration of the original. With dryRun set the report lists the bans that would be added without adding them.
+func SyncBans(targets []*BanSyncTarget, dryRun bool) (*BanSyncReport, error) {
+	existing := make([][]*Ban, len(targets))
+	for i, target := range targets {
+		if target.Sid != 0 {
+			err := target.Connection.Use(int(target.Sid))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		bans, err := target.Connection.BanList(0, 0)
+		if err != nil {
+			return nil, err
+		}
+		existing[i] = bans
+	}
+
+	report := planBanSync(targets, existing, time.Now())
+	if dryRun {
+		return report, nil
+	}
+
+	for _, action := range report.Actions {
+		if action.Target.Sid != 0 {
+			err := action.Target.Connection.Use(int(action.Target.Sid))
+			if err != nil {
+				return report, err
+			}
+		}
+
+		_, err := action.Target.Connection.BanAdd(action.Ban)
+		if err != nil {
+			return report, err
+		}
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+// Computes the union of the active bans (existing[i] being the bans of targets[i]) and which are missing where.
+// When the same rule exists on several targets the one enforced the longest wins.
+func planBanSync(targets []*BanSyncTarget, existing [][]*Ban, now time.Time) *BanSyncReport {
+	report := &BanSyncReport{Bans: make([]*Ban, 0), Actions: make([]*BanSyncAction, 0)}
+
+	union := make(map[string]*Ban)
+	for _, bans := range existing {
+		for _, ban := range bans {
+			if !banActive(ban, now) {
+				continue
+			}
+
+			key := banKey(ban)
+			current, found := union[key]
+			switch {
+			case !found:
+				report.Bans = append(report.Bans, ban)
+			case current.Permanent():
+				continue
+			case !ban.Permanent() && ban.Remaining(now) <= current.Remaining(now):
+				continue
+			default:
+				for i := range report.Bans {
+					if report.Bans[i] == current {
+						report.Bans[i] = ban
+					}
+				}
+			}
+			union[key] = ban
+		}
+	}
+
+	for i, target := range targets {
+		present := make(map[string]bool)
+		for _, ban := range existing[i] {
+			// An expired ban still listed on the target does not enforce the rule anymore
+			if banActive(ban, now) {
+				present[banKey(ban)] = true
+			}
+		}
+
+		for _, ban := range report.Bans {
+			if present[banKey(ban)] {
+				continue
+			}
+
+			// Round the remaining time up so the copy never expires before the original
+			missing := &Ban{Ip: ban.Ip, Name: ban.Name, Uid: ban.Uid, MyTsId: ban.MyTsId, Reason: ban.Reason}
+			if !ban.Permanent() {
+				missing.Duration = uint((ban.Remaining(now) + time.Second - 1) / time.Second)
+			}
+
+			report.Actions = append(report.Actions, &BanSyncAction{Target: target, Ban: missing})
+		}
+	}
+
+	return report
+}
+
+// Reports whether the ban is still enforced as of now
+func banActive(ban *Ban, now time.Time) bool {
+	return ban.Permanent() || ban.Remaining(now) > 0
+}
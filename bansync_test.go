@@ -0,0 +1,59 @@
+package teamspeak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanBanSync(t *testing.T) {
+	now := time.Unix(1400001000, 0)
+	first := &BanSyncTarget{Sid: 1}
+	second := &BanSyncTarget{Sid: 2}
+
+	existing := [][]*Ban{
+		{
+			&Ban{Id: 1, Uid: "troll=", Reason: "Trolling"},
+			&Ban{Id: 2, Ip: "10\\.0\\.0\\..*", Created: 1400000000, Duration: 3600, Reason: "Flooding"},
+			&Ban{Id: 3, Name: "Expired", Created: 1300000000, Duration: 60},
+		},
+		{
+			&Ban{Id: 7, Uid: "troll=", Reason: "Trolling"},
+			&Ban{Id: 8, Ip: "10\\.0\\.0\\..*", Created: 1400000500, Duration: 3600, Reason: "Flooding again"},
+		},
+	}
+
+	report := planBanSync([]*BanSyncTarget{first, second}, existing, now)
+	if len(report.Bans) != 2 {
+		t.Errorf("planBanSync(): Expected 2 active bans, received %v", report.Bans)
+	}
+	if len(report.Actions) != 0 {
+		t.Errorf("planBanSync(): Expected nothing to add, received %v", report.Actions)
+	}
+
+	// Drop the uid ban from the second server, it gets copied over without expiry
+	existing[1] = existing[1][1:]
+	report = planBanSync([]*BanSyncTarget{first, second}, existing, now)
+	if len(report.Actions) != 1 {
+		t.Errorf("planBanSync(): Expected 1 ban to add, received %v", report.Actions)
+	} else if action := report.Actions[0]; action.Target != second || action.Ban.Uid != "troll=" || !action.Ban.Permanent() || action.Ban.Reason != "Trolling" {
+		t.Errorf("planBanSync(): Unexpected action %v %v", action.Target, action.Ban)
+	}
+
+	// Drop the ip ban from the first server, the longer running one from the second server is copied
+	existing[0] = existing[0][:1]
+	report = planBanSync([]*BanSyncTarget{first, second}, existing, now)
+	if len(report.Actions) != 2 {
+		t.Errorf("planBanSync(): Expected 2 bans to add, received %v", report.Actions)
+	} else if action := report.Actions[0]; action.Target != first || action.Ban.Duration != 3100 || action.Ban.Reason != "Flooding again" {
+		t.Errorf("planBanSync(): Unexpected action %v %v", action.Target, action.Ban)
+	}
+
+	// An expired ban with the same rule on the first server does not count as present
+	existing[0] = append(existing[0], &Ban{Id: 4, Ip: "10\\.0\\.0\\..*", Created: 1300000000, Duration: 60})
+	report = planBanSync([]*BanSyncTarget{first, second}, existing, now)
+	if len(report.Actions) != 2 {
+		t.Errorf("planBanSync(): Expected the active ip ban to still be added, received %v", report.Actions)
+	} else if action := report.Actions[0]; action.Target != first || action.Ban.Ip != "10\\.0\\.0\\..*" {
+		t.Errorf("planBanSync(): Unexpected action %v %v", action.Target, action.Ban)
+	}
+}
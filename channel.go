@@ -1,10 +1,7 @@
 package teamspeak
 
 import (
-	"errors"
 	"fmt"
-	"reflect"
-	"strconv"
 	"strings"
 )
 
@@ -58,126 +55,17 @@ func NewChannel(channelStr string) (*Channel, error) {
 	return channel, nil
 }
 
-// Update the properties of the channel with the attributes passed in
+// Update the properties of the channel with the attributes passed in. Unlike the other entities, unknown
+// attributes are rejected: Channel predates the lenient decoding and callers rely on it to catch typos in
+// hand written property strings.
 func (channel *Channel) Deserialize(propertiesStr string) (*Channel, error) {
-	// Get a settable reflection object representing channel
-	reflected_channel := reflect.ValueOf(channel).Elem()
-
-	// Split the tokens and fill in our channel
-	tokens := strings.Split(propertiesStr, " ")
-	for _, token := range tokens {
-		attribute := strings.SplitN(token, "=", 2)
-
-		if len(attribute) == 2 {
-			fieldFound := false
-
-			// Loop through the fields on Channel and assign the field
-			for i := 0; i < reflected_channel.NumField(); i++ {
-				field := reflected_channel.Field(i)
-
-				// Grab meta information on the Channel's type field
-				fieldTag := reflected_channel.Type().Field(i).Tag
-
-				// See if the attribute matches the "sq" tag on the struct field
-				if attribute[0] == fieldTag.Get("sq") {
-					fieldFound = true
-
-					// Base on the type of field parse appropriately
-					switch field.Kind() {
-					case reflect.Uint:
-						value, err := strconv.ParseUint(attribute[1], 10, 32)
-						if err != nil {
-							return channel, err
-						}
-
-						field.SetUint(value)
-
-					case reflect.Int:
-						value, err := strconv.ParseInt(attribute[1], 10, 32)
-						if err != nil {
-							return channel, err
-						}
-						field.SetInt(value)
-
-					case reflect.Bool:
-						value, err := strconv.ParseBool(attribute[1])
-						if err != nil {
-							return channel, err
-						}
-						field.SetBool(value)
-
-					case reflect.String:
-						field.SetString(Unescape(attribute[1]))
-
-					default:
-						return channel, errors.New(fmt.Sprintf("Cannot handle valid parameter (%v) type %v not supported", attribute[0], field.Kind()))
-					}
-
-					break
-				}
-			}
-
-			// If the field is not found, raise an error
-			if !fieldFound {
-				return channel, errors.New(fmt.Sprintf("Error invalid parameter detected (%v) from %v", attribute[0], propertiesStr))
-			}
-		}
-	}
-
-	return channel, nil
+	err := decodePropertiesWith(channel, propertiesStr, true)
+	return channel, err
 }
 
+// Builds the property string for the listed fields (comma separated, e.g. "Name,MaxClients")
 func (channel *Channel) Serialize(fieldsStr string) (string, error) {
-	// Get a settable reflection object representing channel
-	channelType := reflect.ValueOf(channel).Elem()
-
-	// Parse out the fields we need to serialize
-	fields := strings.Split(fieldsStr, ",")
-	var properties []string
-
-	if len(fields) > 0 && len(fieldsStr) > 0 {
-		// Allocate the slice
-		properties = make([]string, len(fields))
-
-		// Iterate over the fields to retrieve
-		for i, field_name := range fields {
-			field := channelType.FieldByName(field_name)
-
-			// Grab a copy of the field from the type (if it exists)
-			fieldType, found := channelType.Type().FieldByName(field_name)
-
-			// Should the field exist carry on and grab the value
-			if field.IsValid() && found {
-				switch field.Kind() {
-				case reflect.Uint:
-					properties[i] = fmt.Sprintf("%v=%d", fieldType.Tag.Get("sq"), field.Uint())
-
-				case reflect.String:
-					properties[i] = fmt.Sprintf("%v=%s", fieldType.Tag.Get("sq"), Escape(field.String()))
-
-				case reflect.Int:
-					properties[i] = fmt.Sprintf("%v=%d", fieldType.Tag.Get("sq"), field.Int())
-
-				case reflect.Bool:
-					if field.Bool() {
-						properties[i] = fmt.Sprintf("%v=1", fieldType.Tag.Get("sq"))
-					} else {
-						properties[i] = fmt.Sprintf("%v=0", fieldType.Tag.Get("sq"))
-					}
-
-				default:
-					return "", errors.New(fmt.Sprintf("Cannot handle valid parameter (%v) type %v not supported", field_name, field.Kind()))
-				}
-			} else {
-				return "", errors.New(fmt.Sprintf("Field %v not found on Channel", field_name))
-			}
-		}
-	} else {
-		return "", errors.New("No fields listed")
-	}
-
-	// Join all the properties together and return
-	return strings.Join(properties, " "), nil
+	return encodeProperties(channel, splitFields(fieldsStr))
 }
 
 // Reads the list of channels
@@ -231,3 +119,33 @@ func (ts3 *Connection) ChannelEdit(channel *Channel, fields string) error {
 
 	return nil
 }
+
+// Lists the permissions assigned to the channel, permsid returns the permission names rather than ids
+func (ts3 *Connection) ChannelPermList(cid uint, permsid bool) ([]*Permission, error) {
+	return ts3.permList(fmt.Sprintf("channelpermlist cid=%d", cid), permsid)
+}
+
+// Adds or updates one or more permissions on the channel
+func (ts3 *Connection) ChannelAddPerm(cid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channeladdperm cid=%d", cid), perms, permValue)
+}
+
+// Removes one or more permissions from the channel
+func (ts3 *Connection) ChannelDelPerm(cid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channeldelperm cid=%d", cid), perms, permIdentifier)
+}
+
+// Lists the permissions assigned to the client (by database id) in the channel
+func (ts3 *Connection) ChannelClientPermList(cid, cldbid uint, permsid bool) ([]*Permission, error) {
+	return ts3.permList(fmt.Sprintf("channelclientpermlist cid=%d cldbid=%d", cid, cldbid), permsid)
+}
+
+// Adds or updates one or more permissions for the client (by database id) in the channel
+func (ts3 *Connection) ChannelClientAddPerm(cid, cldbid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channelclientaddperm cid=%d cldbid=%d", cid, cldbid), perms, permValue)
+}
+
+// Removes one or more permissions for the client (by database id) in the channel
+func (ts3 *Connection) ChannelClientDelPerm(cid, cldbid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channelclientdelperm cid=%d cldbid=%d", cid, cldbid), perms, permIdentifier)
+}
@@ -0,0 +1,153 @@
+package teamspeak
+
+import (
+	"fmt"
+)
+
+type ChannelGroup struct {
+	Cgid                    uint   `sq:"cgid"`
+	Name                    string `sq:"name"`
+	Type                    uint   `sq:"type"`
+	IconId                  int    `sq:"iconid"`
+	SaveDb                  bool   `sq:"savedb"`
+	SortId                  uint   `sq:"sortid"`
+	NameMode                uint   `sq:"namemode"`
+	NeededModifyPower       uint   `sq:"n_modifyp"`
+	NeededMemberAddPower    uint   `sq:"n_member_addp"`
+	NeededMemberRemovePower uint   `sq:"n_member_removep"`
+}
+
+// The channel group a client (by database id) holds in a channel
+type ChannelGroupMember struct {
+	Cid    uint `sq:"cid"`
+	Cldbid uint `sq:"cldbid"`
+	Cgid   uint `sq:"cgid"`
+}
+
+func NewChannelGroup(channelGroupStr string) (*ChannelGroup, error) {
+	channelGroup := &ChannelGroup{}
+
+	err := decodeProperties(channelGroup, channelGroupStr)
+	if err != nil {
+		return channelGroup, err
+	}
+
+	return channelGroup, nil
+}
+
+// Reads the list of channel groups on the selected virtual server
+func (ts3 *Connection) ChannelGroupList() ([]*ChannelGroup, error) {
+	response, err := ts3.exec("channelgrouplist")
+	if err != nil {
+		return make([]*ChannelGroup, 0), err
+	}
+
+	rawChannelGroups := splitEntries(response)
+	channelGroups := make([]*ChannelGroup, len(rawChannelGroups))
+	for i, rawChannelGroup := range rawChannelGroups {
+		channelGroup, err := NewChannelGroup(rawChannelGroup)
+		if err != nil {
+			return channelGroups, err
+		}
+		channelGroups[i] = channelGroup
+	}
+
+	return channelGroups, nil
+}
+
+// Creates a new channel group of the given type (see GroupType*), returning its id
+func (ts3 *Connection) ChannelGroupAdd(name string, groupType uint) (uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("channelgroupadd name=%v type=%d", Escape(name), groupType))
+	if err != nil {
+		return 0, err
+	}
+
+	created := struct {
+		Cgid uint `sq:"cgid"`
+	}{}
+	err = decodeProperties(&created, response)
+
+	return created.Cgid, err
+}
+
+// Deletes the channel group, force deletes it even when clients are still members
+func (ts3 *Connection) ChannelGroupDel(cgid uint, force bool) error {
+	_, err := ts3.exec(fmt.Sprintf("channelgroupdel cgid=%d force=%d", cgid, boolFlag(force)))
+	return err
+}
+
+// Changes the name of the channel group
+func (ts3 *Connection) ChannelGroupRename(cgid uint, name string) error {
+	_, err := ts3.exec(fmt.Sprintf("channelgrouprename cgid=%d name=%v", cgid, Escape(name)))
+	return err
+}
+
+// Copies the source channel group onto the target group, a target of 0 creates a new group whose id is returned
+func (ts3 *Connection) ChannelGroupCopy(scgid, tcgid uint, name string, groupType uint) (uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("channelgroupcopy scgid=%d tcgid=%d name=%v type=%d", scgid, tcgid, Escape(name), groupType))
+	if err != nil {
+		return 0, err
+	}
+
+	created := struct {
+		Cgid uint `sq:"cgid"`
+	}{tcgid}
+	err = decodeProperties(&created, response)
+
+	return created.Cgid, err
+}
+
+// Lists the permissions assigned to the channel group, permsid returns the permission names rather than ids
+func (ts3 *Connection) ChannelGroupPermList(cgid uint, permsid bool) ([]*Permission, error) {
+	return ts3.permList(fmt.Sprintf("channelgrouppermlist cgid=%d", cgid), permsid)
+}
+
+// Adds or updates one or more permissions on the channel group
+func (ts3 *Connection) ChannelGroupAddPerm(cgid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channelgroupaddperm cgid=%d", cgid), perms, permValue)
+}
+
+// Removes one or more permissions from the channel group
+func (ts3 *Connection) ChannelGroupDelPerm(cgid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("channelgroupdelperm cgid=%d", cgid), perms, permIdentifier)
+}
+
+// Lists the channel group assignments, each of cid, cldbid and cgid filters the list when non zero
+func (ts3 *Connection) ChannelGroupClientList(cid, cldbid, cgid uint) ([]*ChannelGroupMember, error) {
+	command := "channelgroupclientlist"
+	if cid != 0 {
+		command += fmt.Sprintf(" cid=%d", cid)
+	}
+	if cldbid != 0 {
+		command += fmt.Sprintf(" cldbid=%d", cldbid)
+	}
+	if cgid != 0 {
+		command += fmt.Sprintf(" cgid=%d", cgid)
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*ChannelGroupMember, 0), nil
+	}
+	if err != nil {
+		return make([]*ChannelGroupMember, 0), err
+	}
+
+	rawMembers := splitEntries(response)
+	members := make([]*ChannelGroupMember, len(rawMembers))
+	for i, rawMember := range rawMembers {
+		members[i] = &ChannelGroupMember{}
+		err = decodeProperties(members[i], rawMember)
+		if err != nil {
+			return members, err
+		}
+	}
+
+	return members, nil
+}
+
+// Assigns the channel group to the client (by database id) in the channel
+func (ts3 *Connection) SetClientChannelGroup(cgid, cid, cldbid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("setclientchannelgroup cgid=%d cid=%d cldbid=%d", cgid, cid, cldbid))
+	return err
+}
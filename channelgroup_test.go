@@ -0,0 +1,18 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validChannelGroupString = "cgid=5 name=Channel\\sAdmin type=1 iconid=100 savedb=1 sortid=0 namemode=0 n_modifyp=75 n_member_addp=50 n_member_removep=50"
+
+func TestNewChannelGroup(t *testing.T) {
+	channelGroup, err := NewChannelGroup(validChannelGroupString)
+	if err != nil {
+		t.Errorf("NewChannelGroup(\"%v\"): Errored out with %v", validChannelGroupString, err)
+	} else {
+		if channelGroup.Cgid != 5 || channelGroup.Name != "Channel Admin" || channelGroup.Type != GroupTypeRegular || channelGroup.IconId != 100 || !channelGroup.SaveDb || channelGroup.NeededMemberAddPower != 50 {
+			t.Errorf("NewChannelGroup(\"%v\"): Parsed version %v does not match source input", validChannelGroupString, channelGroup)
+		}
+	}
+}
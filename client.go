@@ -0,0 +1,117 @@
+package teamspeak
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Lists the permissions assigned directly to the client (by database id), permsid returns the permission names
+// rather than ids
+func (ts3 *Connection) ClientPermList(cldbid uint, permsid bool) ([]*Permission, error) {
+	return ts3.permList(fmt.Sprintf("clientpermlist cldbid=%d", cldbid), permsid)
+}
+
+// Adds or updates one or more permissions directly on the client (by database id)
+func (ts3 *Connection) ClientAddPerm(cldbid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("clientaddperm cldbid=%d", cldbid), perms, permSkip)
+}
+
+// Removes one or more permissions from the client (by database id)
+func (ts3 *Connection) ClientDelPerm(cldbid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("clientdelperm cldbid=%d", cldbid), perms, permIdentifier)
+}
+
+// Properties of the query client itself, changed through ClientUpdate
+type ClientSelf struct {
+	Nickname           string `sq:"client_nickname"`
+	Description        string `sq:"client_description"`
+	IsTalker           bool   `sq:"client_is_talker"`
+	IsChannelCommander bool   `sq:"client_is_channel_commander"`
+	IconId             int    `sq:"client_icon_id"`
+	Away               bool   `sq:"client_away"`
+	AwayMessage        string `sq:"client_away_message"`
+}
+
+// Changes the listed fields (comma separated, e.g. "Nickname,Description") of the query client itself
+func (ts3 *Connection) ClientUpdate(client *ClientSelf, fields string) error {
+	fieldNames := splitFields(fields)
+
+	propertyString, err := encodeProperties(client, fieldNames)
+	if err != nil {
+		return err
+	}
+
+	_, err = ts3.exec(fmt.Sprintf("clientupdate %v", propertyString))
+	if err != nil {
+		return err
+	}
+
+	for _, fieldName := range fieldNames {
+		if fieldName == "Nickname" {
+			ts3.session.Nickname = client.Nickname
+		}
+	}
+
+	return nil
+}
+
+// Changes the nickname the query client is shown with
+func (ts3 *Connection) SetNickname(nickname string) error {
+	return ts3.ClientUpdate(&ClientSelf{Nickname: nickname}, "Nickname")
+}
+
+// Moves the client into the channel, password is only needed for password protected channels
+func (ts3 *Connection) ClientMove(clid, cid uint, password string) error {
+	command := fmt.Sprintf("clientmove clid=%d cid=%d", clid, cid)
+	if len(password) > 0 {
+		command += fmt.Sprintf(" cpw=%v", Escape(password))
+	}
+
+	_, err := ts3.exec(command)
+	return err
+}
+
+// Moves the query client itself into the channel, e.g. to receive the text messages sent in that channel
+func (ts3 *Connection) MoveSelf(cid uint, password string) error {
+	if ts3.session.Clid == 0 {
+		_, err := ts3.WhoAmI()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := ts3.ClientMove(ts3.session.Clid, cid, password)
+	if err != nil {
+		return err
+	}
+
+	ts3.session.Cid = cid
+	return nil
+}
+
+// Lists the ids of the server groups the online client is a member of
+func (ts3 *Connection) ClientServerGroups(clid uint) ([]uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("clientinfo clid=%d", clid))
+	if err != nil {
+		return nil, err
+	}
+
+	info := struct {
+		ServerGroups string `sq:"client_servergroups"`
+	}{}
+	err = decodeProperties(&info, response)
+	if err != nil {
+		return nil, err
+	}
+
+	sgids := make([]uint, 0)
+	for _, rawSgid := range splitFields(info.ServerGroups) {
+		sgid, err := strconv.ParseUint(rawSgid, 10, 32)
+		if err != nil {
+			return sgids, err
+		}
+		sgids = append(sgids, uint(sgid))
+	}
+
+	return sgids, nil
+}
@@ -0,0 +1,29 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+func TestClientSelf(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"clientupdate client_nickname=Helpdesk\\sBot client_description=Ask\\sme", "error id=0 msg=ok"},
+		{"whoami", "virtualserver_status=online virtualserver_id=1 virtualserver_port=9987 client_id=7 client_channel_id=1 client_nickname=Helpdesk\\sBot client_database_id=1 client_login_name=serveradmin\n\rerror id=0 msg=ok"},
+		{"clientmove clid=7 cid=4", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	err := ts3.ClientUpdate(&ClientSelf{Nickname: "Helpdesk Bot", Description: "Ask me"}, "Nickname,Description")
+	if err != nil {
+		t.Errorf("ts3.ClientUpdate(): Errored out with %v", err)
+	} else if ts3.Session().Nickname != "Helpdesk Bot" {
+		t.Errorf("ts3.ClientUpdate(): Did not track the new nickname, %v", ts3.Session())
+	}
+
+	// The client id is looked up before moving
+	err = ts3.MoveSelf(4, "")
+	if err != nil {
+		t.Errorf("ts3.MoveSelf(4): Errored out with %v", err)
+	} else if ts3.Session().Cid != 4 {
+		t.Errorf("ts3.MoveSelf(4): Did not track the new channel, %v", ts3.Session())
+	}
+}
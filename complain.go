@@ -0,0 +1,78 @@
+package teamspeak
+
+import (
+	"fmt"
+	"time"
+)
+
+// A complaint filed by one client (From) against another (Target)
+type Complaint struct {
+	TargetCldbid uint   `sq:"tcldbid"`
+	TargetName   string `sq:"tname"`
+	FromCldbid   uint   `sq:"fcldbid"`
+	FromName     string `sq:"fname"`
+	Message      string `sq:"message"`
+	Timestamp    int64  `sq:"timestamp"`
+}
+
+func NewComplaint(complaintStr string) (*Complaint, error) {
+	complaint := &Complaint{}
+
+	err := decodeProperties(complaint, complaintStr)
+	if err != nil {
+		return complaint, err
+	}
+
+	return complaint, nil
+}
+
+// When the complaint was filed
+func (complaint *Complaint) FiledAt() time.Time {
+	return time.Unix(complaint.Timestamp, 0)
+}
+
+// Lists the complaints on the selected virtual server, a non zero tcldbid only lists those against that client
+func (ts3 *Connection) ComplainList(tcldbid uint) ([]*Complaint, error) {
+	command := "complainlist"
+	if tcldbid != 0 {
+		command += fmt.Sprintf(" tcldbid=%d", tcldbid)
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*Complaint, 0), nil
+	}
+	if err != nil {
+		return make([]*Complaint, 0), err
+	}
+
+	rawComplaints := splitEntries(response)
+	complaints := make([]*Complaint, len(rawComplaints))
+	for i, rawComplaint := range rawComplaints {
+		complaint, err := NewComplaint(rawComplaint)
+		if err != nil {
+			return complaints, err
+		}
+		complaints[i] = complaint
+	}
+
+	return complaints, nil
+}
+
+// Files a complaint against the client (by database id)
+func (ts3 *Connection) ComplainAdd(tcldbid uint, message string) error {
+	_, err := ts3.exec(fmt.Sprintf("complainadd tcldbid=%d message=%v", tcldbid, Escape(message)))
+	return err
+}
+
+// Deletes the complaint filed by fcldbid against tcldbid
+func (ts3 *Connection) ComplainDel(tcldbid, fcldbid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("complaindel tcldbid=%d fcldbid=%d", tcldbid, fcldbid))
+	return err
+}
+
+// Deletes every complaint against the client (by database id)
+func (ts3 *Connection) ComplainDelAll(tcldbid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("complaindelall tcldbid=%d", tcldbid))
+	return err
+}
@@ -0,0 +1,19 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validComplaintString = "tcldbid=3 tname=Troll fcldbid=2 fname=Alice message=Keeps\\sspamming\\slinks timestamp=1400000000"
+
+func TestNewComplaint(t *testing.T) {
+	complaint, err := NewComplaint(validComplaintString)
+	if err != nil {
+		t.Errorf("NewComplaint(\"%v\"): Errored out with %v", validComplaintString, err)
+		return
+	}
+
+	if complaint.TargetCldbid != 3 || complaint.TargetName != "Troll" || complaint.FromCldbid != 2 || complaint.FromName != "Alice" || complaint.Message != "Keeps spamming links" || complaint.FiledAt().Unix() != 1400000000 {
+		t.Errorf("NewComplaint(\"%v\"): Parsed version %v does not match source input", validComplaintString, complaint)
+	}
+}
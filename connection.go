@@ -9,9 +9,16 @@ import (
 )
 
 type Connection struct {
-	conn  *net.TCPConn
-	rw    *bufio.ReadWriter
-	Debug bool
+	conn          net.Conn
+	rw            *bufio.ReadWriter
+	permissions   *permissionCatalog
+	session       Session
+	notifications []*Notification
+	ftfid         uint
+	Debug         bool
+
+	// Host to connect to for file transfers, defaults to the host of the ServerQuery connection
+	TransferHost string
 }
 
 // Generates a new connection, dials out, and verifies connectivity
@@ -22,15 +29,20 @@ func NewConnection(connectionString string) (*Connection, error) {
 		return nil, err
 	}
 
-	// Set up the object to return
-	ts3 := &Connection{}
-
 	// Dial the remote address
-	ts3.conn, err = net.DialTCP("tcp", nil, tsAddr)
+	conn, err := net.DialTCP("tcp", nil, tsAddr)
 	if err != nil {
 		return nil, err
 	}
 
+	return newConnection(conn)
+}
+
+// Wraps an established connection and verifies the remote end is a TS3 server
+func newConnection(conn net.Conn) (*Connection, error) {
+	// Set up the object to return
+	ts3 := &Connection{conn: conn}
+
 	// Setup the reader and writer
 	reader := bufio.NewReader(ts3.conn)
 	writer := bufio.NewWriter(ts3.conn)
@@ -72,6 +84,16 @@ func (ts3 *Connection) SendCommand(command string) (string, error) {
 	return ts3.ReadResponse()
 }
 
+// Sends the command, treating the "error id=0" trailer as success (nil error)
+func (ts3 *Connection) exec(command string) (string, error) {
+	response, err := ts3.SendCommand(command)
+	if ts3Err, ok := err.(*Error); ok && ts3Err.Id == ErrorOk {
+		return response, nil
+	}
+
+	return response, err
+}
+
 func (ts3 *Connection) ReadResponse() (string, error) {
 	// Generate the response data structure
 	responseBuffer := make([]byte, 0)
@@ -79,31 +101,25 @@ func (ts3 *Connection) ReadResponse() (string, error) {
 
 	continueReadingResponse := true
 	for continueReadingResponse {
-		lineBuffer := make([]byte, 0)
-
-		// Read the response
-		for continueReadingLine := true; continueReadingLine; {
-			rawResponse, isPrefix, err := ts3.rw.ReadLine()
-			if err != nil {
-				return "", err
-			}
-			lineBuffer = append(lineBuffer, rawResponse...)
-
-			continueReadingLine = isPrefix
+		line, err := ts3.readLine()
+		if err != nil {
+			return "", err
 		}
 
-		if "error" == strings.TrimSpace(string(lineBuffer))[0:5] {
+		if strings.HasPrefix(line, "notify") {
+			// Events are pushed whenever they occur, hold on to them until ReadNotification is called
+			ts3.notifications = append(ts3.notifications, newNotification(line))
+		} else if strings.HasPrefix(line, "error ") {
 			// Last line of response has been detected
 			continueReadingResponse = false
-			var err error
 
-			ts3Err, err = NewError(strings.TrimSpace(string(lineBuffer)))
+			ts3Err, err = NewError(line)
 			if err != nil {
 				return "", err
 			}
 		} else {
 			// Store the text of the response and continue reading (next line will be error related)
-			responseBuffer = append(responseBuffer, lineBuffer...)
+			responseBuffer = append(responseBuffer, line...)
 		}
 	}
 
@@ -119,6 +135,23 @@ func (ts3 *Connection) ReadResponse() (string, error) {
 	return response, ts3Err
 }
 
+// Reads a full line from the server, stripped of surrounding whitespace
+func (ts3 *Connection) readLine() (string, error) {
+	lineBuffer := make([]byte, 0)
+
+	for continueReadingLine := true; continueReadingLine; {
+		rawResponse, isPrefix, err := ts3.rw.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		lineBuffer = append(lineBuffer, rawResponse...)
+
+		continueReadingLine = isPrefix
+	}
+
+	return strings.TrimSpace(string(lineBuffer)), nil
+}
+
 // Closes the ServerQuery connection to the TeamSpeak 3 Server instance.
 func (ts3 *Connection) Quit() error {
 	_, err := ts3.SendCommand("quit")
@@ -135,6 +168,7 @@ func (ts3 *Connection) Quit() error {
 func (ts3 *Connection) Login(username, password string) error {
 	_, err := ts3.SendCommand(fmt.Sprintf("login %v %v", username, password))
 	if ts3Err, ok := err.(*Error); ok && ts3Err.Id == 0 {
+		ts3.session.Username = username
 		return nil
 	}
 
@@ -145,20 +179,101 @@ func (ts3 *Connection) Login(username, password string) error {
 func (ts3 *Connection) Logout() error {
 	_, err := ts3.SendCommand("logout")
 	if ts3Err, ok := err.(*Error); ok && ts3Err.Id == 0 {
+		ts3.session = Session{}
 		return nil
 	}
 
 	return err
 }
 
+// Optional settings applied when selecting a virtual server
+type UseOptions struct {
+	// Select the server even when it is not running
+	Virtual bool
+
+	// Nickname the query client should use on the selected server
+	Nickname string
+}
+
 // Selects the virtual server to act on
-func (ts3 *Connection) Use(serverId int) error {
-	_, err := ts3.SendCommand(fmt.Sprintf("use sid=%d", serverId))
-	if ts3Err, ok := err.(*Error); ok && ts3Err.Id == 0 {
-		return nil
+func (ts3 *Connection) Use(serverId int, options ...UseOptions) error {
+	err := ts3.use(fmt.Sprintf("sid=%d", serverId), useOption(options))
+	if err != nil {
+		return err
 	}
 
-	return err
+	ts3.session.Sid = uint(serverId)
+	ts3.session.Port = 0
+	return nil
+}
+
+// Selects the virtual server listening on the UDP port to act on
+func (ts3 *Connection) UsePort(port uint, options ...UseOptions) error {
+	err := ts3.use(fmt.Sprintf("port=%d", port), useOption(options))
+	if err != nil {
+		return err
+	}
+
+	ts3.session.Sid = 0
+	ts3.session.Port = port
+	return nil
+}
+
+// Selects the virtual server with the name (virtualserver_name) to act on
+func (ts3 *Connection) UseName(name string, options ...UseOptions) error {
+	servers, err := ts3.ServerList(ServerListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var match *VirtualServer
+	for _, server := range servers {
+		if server.Name != name {
+			continue
+		}
+		if match != nil {
+			return errors.New(fmt.Sprintf("Multiple virtual servers named %v", name))
+		}
+		match = server
+	}
+
+	if match == nil {
+		return errors.New(fmt.Sprintf("No virtual server named %v", name))
+	}
+
+	return ts3.Use(int(match.Id), options...)
+}
+
+// Issues the use command for the server selector (sid=... or port=...)
+func (ts3 *Connection) use(selector string, option UseOptions) error {
+	command := "use " + selector
+	if option.Virtual {
+		command += " -virtual"
+	}
+	if len(option.Nickname) > 0 {
+		command += fmt.Sprintf(" client_nickname=%v", Escape(option.Nickname))
+	}
+
+	_, err := ts3.exec(command)
+	if err != nil {
+		return err
+	}
+
+	// The query client lands in the default channel of the newly selected server
+	ts3.session.Clid = 0
+	ts3.session.Cid = 0
+	ts3.session.Nickname = option.Nickname
+
+	return nil
+}
+
+// Returns the first of the optional use options
+func useOption(options []UseOptions) UseOptions {
+	if len(options) > 0 {
+		return options[0]
+	}
+
+	return UseOptions{}
 }
 
 // Closes the TCP Connectionection
@@ -0,0 +1,121 @@
+package teamspeak
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+)
+
+// A command the stand-in server expects and the lines it replies with (the error line included)
+type testExchange struct {
+	command string
+	reply   string
+}
+
+// Connects to a stand-in ServerQuery server that plays back the exchanges in order
+func newTestConnection(t *testing.T, exchanges []testExchange) *Connection {
+	client, server := net.Pipe()
+
+	go func() {
+		defer server.Close()
+
+		rw := bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server))
+		rw.WriteString("TS3\n\rWelcome to the TeamSpeak 3 ServerQuery interface\n\r")
+		rw.Flush()
+
+		for _, exchange := range exchanges {
+			line, err := rw.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.TrimSpace(line)
+			if command != exchange.command {
+				t.Errorf("Stand-in server expected \"%v\", received \"%v\"", exchange.command, command)
+				rw.WriteString("error id=256 msg=command\\snot\\sfound\n\r")
+			} else {
+				rw.WriteString(exchange.reply + "\n\r")
+			}
+			rw.Flush()
+		}
+	}()
+
+	ts3, err := newConnection(client)
+	if err != nil {
+		t.Fatalf("newConnection(): Errored out with %v", err)
+	}
+
+	return ts3
+}
+
+func TestSession(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"login serveradmin secret", "error id=0 msg=ok"},
+		{"use port=9987 client_nickname=Bot", "error id=0 msg=ok"},
+		{"whoami", "virtualserver_status=online virtualserver_id=1 virtualserver_unique_identifier=abc= virtualserver_port=9987 client_id=5 client_channel_id=1 client_nickname=Bot client_database_id=1 client_login_name=serveradmin client_unique_identifier=serveradmin client_origin_server_id=0\n\rerror id=0 msg=ok"},
+		{"logout", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	if ts3.RequireLogin() == nil || ts3.RequireServer() == nil {
+		t.Errorf("ts3.Session(): New connection should be neither logged in nor have a server selected, %v", ts3.Session())
+	}
+
+	if err := ts3.Login("serveradmin", "secret"); err != nil {
+		t.Errorf("ts3.Login(): Errored out with %v", err)
+	}
+	if err := ts3.UsePort(9987, UseOptions{Nickname: "Bot"}); err != nil {
+		t.Errorf("ts3.UsePort(): Errored out with %v", err)
+	}
+
+	session := ts3.Session()
+	if ts3.RequireLogin() != nil || ts3.RequireServer() != nil || session.Username != "serveradmin" || session.Port != 9987 || session.Nickname != "Bot" {
+		t.Errorf("ts3.Session(): Did not track login and server selection, %v", session)
+	}
+
+	whoAmI, err := ts3.WhoAmI()
+	if err != nil {
+		t.Errorf("ts3.WhoAmI(): Errored out with %v", err)
+	} else if whoAmI.ClientId != 5 || whoAmI.ServerId != 1 || ts3.Session().Sid != 1 || ts3.Session().Cid != 1 {
+		t.Errorf("ts3.WhoAmI(): Did not refresh the session from %v, %v", whoAmI, ts3.Session())
+	}
+
+	if err := ts3.Logout(); err != nil {
+		t.Errorf("ts3.Logout(): Errored out with %v", err)
+	}
+	if ts3.Session().LoggedIn() || ts3.Session().ServerSelected() {
+		t.Errorf("ts3.Logout(): Did not reset the session, %v", ts3.Session())
+	}
+}
+
+func TestUse(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"use port=9988 -virtual client_nickname=Night\\sBot", "error id=0 msg=ok"},
+		{"serverlist", "virtualserver_id=1 virtualserver_port=9987 virtualserver_name=Main|virtualserver_id=2 virtualserver_port=9988 virtualserver_name=Games|virtualserver_id=3 virtualserver_port=9989 virtualserver_name=Games\n\rerror id=0 msg=ok"},
+		{"serverlist", "virtualserver_id=1 virtualserver_port=9987 virtualserver_name=Main|virtualserver_id=2 virtualserver_port=9988 virtualserver_name=Games\n\rerror id=0 msg=ok"},
+		{"serverlist", "virtualserver_id=1 virtualserver_port=9987 virtualserver_name=Main|virtualserver_id=2 virtualserver_port=9988 virtualserver_name=Games\n\rerror id=0 msg=ok"},
+		{"use sid=1 client_nickname=Bot", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	if err := ts3.UsePort(9988, UseOptions{Virtual: true, Nickname: "Night Bot"}); err != nil {
+		t.Errorf("ts3.UsePort(): Errored out with %v", err)
+	} else if session := ts3.Session(); session.Port != 9988 || session.Sid != 0 || session.Nickname != "Night Bot" {
+		t.Errorf("ts3.UsePort(): Did not track the selection, %v", session)
+	}
+
+	// Names shared by several servers and unknown names are rejected without issuing use
+	if err := ts3.UseName("Games"); err == nil {
+		t.Errorf("ts3.UseName(Games): Should have thrown an error for an ambiguous name")
+	}
+	if err := ts3.UseName("Missing"); err == nil {
+		t.Errorf("ts3.UseName(Missing): Should have thrown an error for an unknown name")
+	}
+
+	if err := ts3.UseName("Main", UseOptions{Nickname: "Bot"}); err != nil {
+		t.Errorf("ts3.UseName(Main): Errored out with %v", err)
+	} else if session := ts3.Session(); session.Sid != 1 || session.Port != 0 || session.Nickname != "Bot" {
+		t.Errorf("ts3.UseName(Main): Did not track the selection, %v", session)
+	}
+}
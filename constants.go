@@ -6,3 +6,62 @@ const (
 	CodecSpeexUltraWideBand
 	CodecCeltMono
 )
+
+// Server and channel group types
+const (
+	GroupTypeTemplate = iota
+	GroupTypeRegular
+	GroupTypeQuery
+)
+
+// Server group type classes (sgtype) used by the servergroupautoaddperm/servergroupautodelperm commands
+const (
+	ServerGroupTypeChannelGuest    = 10
+	ServerGroupTypeServerGuest     = 15
+	ServerGroupTypeQueryGuest      = 20
+	ServerGroupTypeChannelVoice    = 25
+	ServerGroupTypeServerNormal    = 30
+	ServerGroupTypeChannelOperator = 35
+	ServerGroupTypeChannelAdmin    = 40
+	ServerGroupTypeServerAdmin     = 45
+	ServerGroupTypeQueryAdmin      = 50
+)
+
+// Where a permission is assigned, as reported by permfind and permoverview
+const (
+	PermissionTypeServerGroup = iota
+	PermissionTypeClient
+	PermissionTypeChannel
+	PermissionTypeChannelGroup
+	PermissionTypeChannelClient
+)
+
+// Target modes of sendtextmessage and textmessage notifications
+const (
+	TextMessageTargetClient = iota + 1
+	TextMessageTargetChannel
+	TextMessageTargetServer
+)
+
+// Longest message (in bytes, before escaping) the server accepts in sendtextmessage and gm
+const MaxTextMessageLength = 1024
+
+// Privilege key types, determining whether a key grants a server group or a channel group
+const (
+	TokenTypeServerGroup = iota
+	TokenTypeChannelGroup
+)
+
+// Log levels of logadd and log entries
+const (
+	LogLevelError = iota + 1
+	LogLevelWarning
+	LogLevelDebug
+	LogLevelInfo
+)
+
+// Types of the entries returned by ftgetfilelist
+const (
+	FileTypeDirectory = iota
+	FileTypeFile
+)
@@ -7,9 +7,21 @@ import (
 	"strings"
 )
 
+// Error ids returned by the server that the library acts upon
+const (
+	ErrorOk                            = 0
+	ErrorChannelInvalidPassword        = 781
+	ErrorDatabaseEmptyResultSet        = 1281
+	ErrorFileAlreadyExists             = 2050
+	ErrorFileInvalidPath               = 2051
+	ErrorInsufficientClientPermissions = 2568
+)
+
 type Error struct {
-	Id  uint
-	Msg string
+	Id           uint
+	Msg          string
+	ExtraMsg     string
+	FailedPermId uint
 }
 
 func (e *Error) Error() string {
@@ -33,7 +45,15 @@ func NewError(errorStr string) (*Error, error) {
 				}
 				ts3Err.Id = uint(id)
 			case "msg":
-				ts3Err.Msg = attribute[1]
+				ts3Err.Msg = Unescape(attribute[1])
+			case "extra_msg":
+				ts3Err.ExtraMsg = Unescape(attribute[1])
+			case "failed_permid":
+				permId, err := strconv.ParseUint(attribute[1], 10, 32)
+				if err != nil {
+					return ts3Err, err
+				}
+				ts3Err.FailedPermId = uint(permId)
 			default:
 				// We don't recognize the error, emit an error about the attribute
 				return ts3Err, errors.New(fmt.Sprintf("Error could not parse param: %v", attribute[0]))
@@ -46,3 +66,24 @@ func NewError(errorStr string) (*Error, error) {
 
 	return ts3Err, nil
 }
+
+// Reports whether err is the server telling us a list command had nothing to return
+func isEmptyResult(err error) bool {
+	return isError(err, ErrorDatabaseEmptyResultSet)
+}
+
+// Reports whether err is a server error with one of the ids
+func isError(err error, ids ...uint) bool {
+	ts3Err, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+
+	for _, id := range ids {
+		if ts3Err.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
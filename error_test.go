@@ -31,3 +31,16 @@ func TestNewError(t *testing.T) {
 		t.Errorf("NewError(\"%v\"): Should have thrown an error. Instead received %v", invalidErrorParamString, invalidParamError)
 	}
 }
+
+func TestNewErrorPermissions(t *testing.T) {
+	const permErrorString = "error id=2568 msg=insufficient\\sclient\\spermissions failed_permid=4"
+
+	permError, err := NewError(permErrorString)
+	if err != nil {
+		t.Errorf("NewError(\"%v\"): Errored out with %v", permErrorString, err)
+	} else {
+		if permError.Id != 2568 || permError.Msg != "insufficient client permissions" || permError.FailedPermId != 4 {
+			t.Errorf("NewError(\"%v\"): Parsed version %v does not match source input", permErrorString, permError)
+		}
+	}
+}
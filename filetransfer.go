@@ -0,0 +1,171 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// A file or directory in a channel's file area
+type FileEntry struct {
+	Cid      uint   `sq:"cid"`
+	Path     string `sq:"path"`
+	Name     string `sq:"name"`
+	Size     uint64 `sq:"size"`
+	Datetime int64  `sq:"datetime"`
+	Type     uint   `sq:"type"`
+}
+
+// A file transfer currently running on the selected virtual server
+type FileTransfer struct {
+	Clid         uint    `sq:"clid"`
+	Path         string  `sq:"path"`
+	Name         string  `sq:"name"`
+	Size         uint64  `sq:"size"`
+	SizeDone     uint64  `sq:"sizedone"`
+	ClientFtfid  uint    `sq:"clientftfid"`
+	ServerFtfid  uint    `sq:"serverftfid"`
+	Sender       uint    `sq:"sender"`
+	Status       int     `sq:"status"`
+	CurrentSpeed float64 `sq:"current_speed"`
+	AverageSpeed float64 `sq:"average_speed"`
+	Runtime      uint64  `sq:"runtime"`
+}
+
+// Reports whether the entry is a directory
+func (entry *FileEntry) IsDir() bool {
+	return entry.Type == FileTypeDirectory
+}
+
+// The full path of the entry within the channel's file area
+func (entry *FileEntry) FullPath() string {
+	return path.Join(entry.Path, entry.Name)
+}
+
+// When the entry was last modified
+func (entry *FileEntry) ModifiedAt() time.Time {
+	return time.Unix(entry.Datetime, 0)
+}
+
+// Parses a list of file entries, cid and path are only reported with the first entry and apply to all of them
+func parseFileEntries(response string) ([]*FileEntry, error) {
+	rawEntries := splitEntries(response)
+	entries := make([]*FileEntry, len(rawEntries))
+
+	for i, rawEntry := range rawEntries {
+		entries[i] = &FileEntry{}
+		if i > 0 {
+			entries[i].Cid = entries[i-1].Cid
+			entries[i].Path = entries[i-1].Path
+		}
+
+		err := decodeProperties(entries[i], rawEntry)
+		if err != nil {
+			return entries, err
+		}
+	}
+
+	return entries, nil
+}
+
+// Lists the files and directories in the directory (e.g. "/") of the channel's file area, password is the channel
+// password (if any)
+func (ts3 *Connection) FtGetFileList(cid uint, password, dir string) ([]*FileEntry, error) {
+	response, err := ts3.exec(fmt.Sprintf("ftgetfilelist cid=%d cpw=%v path=%v", cid, Escape(password), Escape(dir)))
+	if isEmptyResult(err) {
+		return make([]*FileEntry, 0), nil
+	}
+	if err != nil {
+		return make([]*FileEntry, 0), err
+	}
+
+	return parseFileEntries(response)
+}
+
+// Reads the size and modification time of the file (full path, e.g. "/docs/rules.txt") in the channel's file area
+func (ts3 *Connection) FtGetFileInfo(cid uint, password, name string) (*FileEntry, error) {
+	response, err := ts3.exec(fmt.Sprintf("ftgetfileinfo cid=%d cpw=%v name=%v", cid, Escape(password), Escape(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseFileEntries(response)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New(fmt.Sprintf("No file info returned for %v", name))
+	}
+
+	// Some server versions report the full path as the name
+	entry := entries[0]
+	if len(entry.Path) == 0 && strings.HasPrefix(entry.Name, "/") {
+		entry.Path, entry.Name = path.Split(entry.Name)
+	}
+
+	return entry, nil
+}
+
+// Creates the directory (full path) in the channel's file area
+func (ts3 *Connection) FtCreateDir(cid uint, password, dirname string) error {
+	_, err := ts3.exec(fmt.Sprintf("ftcreatedir cid=%d cpw=%v dirname=%v", cid, Escape(password), Escape(dirname)))
+	return err
+}
+
+// Renames (or moves) a file within the channel's file area
+func (ts3 *Connection) FtRenameFile(cid uint, password, oldname, newname string) error {
+	_, err := ts3.exec(fmt.Sprintf("ftrenamefile cid=%d cpw=%v oldname=%v newname=%v", cid, Escape(password), Escape(oldname), Escape(newname)))
+	return err
+}
+
+// Moves a file into the file area of another channel
+func (ts3 *Connection) FtMoveFile(cid uint, password string, tcid uint, tpassword, oldname, newname string) error {
+	_, err := ts3.exec(fmt.Sprintf("ftrenamefile cid=%d cpw=%v tcid=%d tcpw=%v oldname=%v newname=%v", cid, Escape(password), tcid, Escape(tpassword), Escape(oldname), Escape(newname)))
+	return err
+}
+
+// Deletes one or more files (full paths) from the channel's file area
+func (ts3 *Connection) FtDeleteFile(cid uint, password string, names []string) error {
+	if len(names) == 0 {
+		return errors.New("No files listed")
+	}
+
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = fmt.Sprintf("name=%v", Escape(name))
+	}
+
+	_, err := ts3.exec(fmt.Sprintf("ftdeletefile cid=%d cpw=%v %v", cid, Escape(password), strings.Join(entries, "|")))
+	return err
+}
+
+// Lists the file transfers running on the selected virtual server
+func (ts3 *Connection) FtList() ([]*FileTransfer, error) {
+	response, err := ts3.exec("ftlist")
+	if isEmptyResult(err) {
+		return make([]*FileTransfer, 0), nil
+	}
+	if err != nil {
+		return make([]*FileTransfer, 0), err
+	}
+
+	rawTransfers := splitEntries(response)
+	transfers := make([]*FileTransfer, len(rawTransfers))
+	for i, rawTransfer := range rawTransfers {
+		transfers[i] = &FileTransfer{}
+		err = decodeProperties(transfers[i], rawTransfer)
+		if err != nil {
+			return transfers, err
+		}
+	}
+
+	return transfers, nil
+}
+
+// Stops the file transfer, deleting the partially transferred file when requested
+func (ts3 *Connection) FtStop(serverftfid uint, deleteFile bool) error {
+	_, err := ts3.exec(fmt.Sprintf("ftstop serverftfid=%d delete=%d", serverftfid, boolFlag(deleteFile)))
+	return err
+}
@@ -0,0 +1,29 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validFileListString = "cid=2 path=\\/ name=Stuff size=0 datetime=1259415210 type=0|name=Pic1.PNG size=563783 datetime=1259425462 type=1"
+
+func TestParseFileEntries(t *testing.T) {
+	entries, err := parseFileEntries(validFileListString)
+	if err != nil {
+		t.Errorf("parseFileEntries(\"%v\"): Errored out with %v", validFileListString, err)
+		return
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("parseFileEntries(\"%v\"): Expected 2 entries, received %d", validFileListString, len(entries))
+		return
+	}
+
+	if !entries[0].IsDir() || entries[0].FullPath() != "/Stuff" || entries[0].Cid != 2 {
+		t.Errorf("parseFileEntries(\"%v\"): Parsed version %v does not match source input", validFileListString, entries[0])
+	}
+
+	// cid and path carry over from the first entry
+	if entries[1].IsDir() || entries[1].Cid != 2 || entries[1].FullPath() != "/Pic1.PNG" || entries[1].Size != 563783 || entries[1].ModifiedAt().Unix() != 1259425462 {
+		t.Errorf("parseFileEntries(\"%v\"): Parsed version %v does not match source input", validFileListString, entries[1])
+	}
+}
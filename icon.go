@@ -0,0 +1,95 @@
+package teamspeak
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Icons with ids below this are built into the client and cannot be uploaded or deleted
+const builtinIconLimit = 1000
+
+// Computes the id the server expects for the icon, a CRC32 of its contents
+func IconId(data []byte) uint32 {
+	return crc32.ChecksumIEEE(data)
+}
+
+// The name an icon is stored under in the file area of channel 0
+func iconFileName(iconId uint32) string {
+	return fmt.Sprintf("/icon_%d", iconId)
+}
+
+// Converts an icon id to the signed value stored in *_icon_id properties and the i_icon_id permission
+func iconValue(iconId uint32) int {
+	return int(int32(iconId))
+}
+
+// Uploads the icon (PNG data, at most 16x16 pixels) and returns its id
+func (ts3 *Connection) UploadIcon(reader io.Reader) (uint32, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	iconId := IconId(data)
+	err = ts3.Upload(0, iconFileName(iconId), bytes.NewReader(data), TransferOptions{Overwrite: true})
+
+	return iconId, err
+}
+
+// Lists the ids of the icons uploaded to the selected virtual server
+func (ts3 *Connection) IconList() ([]uint32, error) {
+	entries, err := ts3.FtGetFileList(0, "", "/icons/")
+	if err != nil {
+		return make([]uint32, 0), err
+	}
+
+	iconIds := make([]uint32, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name, "icon_") {
+			continue
+		}
+
+		iconId, err := strconv.ParseUint(strings.TrimPrefix(entry.Name, "icon_"), 10, 32)
+		if err != nil {
+			continue
+		}
+		iconIds = append(iconIds, uint32(iconId))
+	}
+
+	return iconIds, nil
+}
+
+// Deletes an uploaded icon
+func (ts3 *Connection) DeleteIcon(iconId uint32) error {
+	if iconId < builtinIconLimit {
+		return errors.New(fmt.Sprintf("Icon %d is built in and cannot be deleted", iconId))
+	}
+
+	return ts3.FtDeleteFile(0, "", []string{iconFileName(iconId)})
+}
+
+// Shows the icon next to the channel, an icon id of 0 removes it
+func (ts3 *Connection) SetChannelIcon(cid uint, iconId uint32) error {
+	_, err := ts3.exec(fmt.Sprintf("channeledit cid=%d channel_icon_id=%d", cid, iconValue(iconId)))
+	return err
+}
+
+// Shows the icon next to members of the server group, an icon id of 0 removes it
+func (ts3 *Connection) SetServerGroupIcon(sgid uint, iconId uint32) error {
+	return ts3.ServerGroupAddPerm(sgid, []*Permission{&Permission{Name: "i_icon_id", Value: iconValue(iconId)}})
+}
+
+// Shows the icon next to members of the channel group, an icon id of 0 removes it
+func (ts3 *Connection) SetChannelGroupIcon(cgid uint, iconId uint32) error {
+	return ts3.ChannelGroupAddPerm(cgid, []*Permission{&Permission{Name: "i_icon_id", Value: iconValue(iconId)}})
+}
+
+// Shows the icon next to the client (by database id), an icon id of 0 removes it
+func (ts3 *Connection) SetClientIcon(cldbid uint, iconId uint32) error {
+	return ts3.ClientAddPerm(cldbid, []*Permission{&Permission{Name: "i_icon_id", Value: iconValue(iconId)}})
+}
@@ -0,0 +1,40 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+func TestIconId(t *testing.T) {
+	// CRC32 (IEEE) of the data
+	if iconId := IconId([]byte("123456789")); iconId != 0xCBF43926 {
+		t.Errorf("IconId(\"123456789\"): Expected %d, received %d", uint32(0xCBF43926), iconId)
+	}
+
+	// Ids above the int32 range are stored as negative values
+	if value := iconValue(0xCBF43926); value != -873187034 {
+		t.Errorf("iconValue(%d): Expected -873187034, received %d", uint32(0xCBF43926), value)
+	}
+}
+
+func TestSetServerGroupIcon(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"servergroupaddperm sgid=6 permsid=i_icon_id permvalue=-873187034 permnegated=0 permskip=0", "error id=0 msg=ok"},
+		{"ftgetfilelist cid=0 cpw= path=\\/icons\\/", "cid=0 path=\\/icons\\/ name=icon_3421086502 size=512 datetime=1400000000 type=1|name=readme.txt size=1 datetime=1400000000 type=1\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	if err := ts3.SetServerGroupIcon(6, 0xCBF43926); err != nil {
+		t.Errorf("ts3.SetServerGroupIcon(): Errored out with %v", err)
+	}
+
+	iconIds, err := ts3.IconList()
+	if err != nil {
+		t.Errorf("ts3.IconList(): Errored out with %v", err)
+	} else if len(iconIds) != 1 || iconIds[0] != 3421086502 {
+		t.Errorf("ts3.IconList(): Expected [3421086502], received %v", iconIds)
+	}
+
+	if err = ts3.DeleteIcon(300); err == nil {
+		t.Errorf("ts3.DeleteIcon(300): Should have refused to delete a built in icon")
+	}
+}
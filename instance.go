@@ -0,0 +1,196 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Instance wide uptime and traffic, as returned by hostinfo
+type HostInfo struct {
+	Timestamp                      int64  `sq:"host_timestamp_utc"`
+	Uptime                         int64  `sq:"instance_uptime"`
+	VirtualServersRunning          uint   `sq:"virtualservers_running_total"`
+	MaxClients                     uint   `sq:"virtualservers_total_maxclients"`
+	ClientsOnline                  uint   `sq:"virtualservers_total_clients_online"`
+	ChannelsOnline                 uint   `sq:"virtualservers_total_channels_online"`
+	FileTransferBandwidthSent      uint64 `sq:"connection_filetransfer_bandwidth_sent"`
+	FileTransferBandwidthReceived  uint64 `sq:"connection_filetransfer_bandwidth_received"`
+	FileTransferBytesSentTotal     uint64 `sq:"connection_filetransfer_bytes_sent_total"`
+	FileTransferBytesReceivedTotal uint64 `sq:"connection_filetransfer_bytes_received_total"`
+	PacketsSentTotal               uint64 `sq:"connection_packets_sent_total"`
+	BytesSentTotal                 uint64 `sq:"connection_bytes_sent_total"`
+	PacketsReceivedTotal           uint64 `sq:"connection_packets_received_total"`
+	BytesReceivedTotal             uint64 `sq:"connection_bytes_received_total"`
+	BandwidthSentLastSecond        uint64 `sq:"connection_bandwidth_sent_last_second_total"`
+	BandwidthSentLastMinute        uint64 `sq:"connection_bandwidth_sent_last_minute_total"`
+	BandwidthReceivedLastSecond    uint64 `sq:"connection_bandwidth_received_last_second_total"`
+	BandwidthReceivedLastMinute    uint64 `sq:"connection_bandwidth_received_last_minute_total"`
+}
+
+// Instance wide settings, as returned by instanceinfo
+type Instance struct {
+	DatabaseVersion                uint   `sq:"serverinstance_database_version"`
+	FileTransferPort               uint   `sq:"serverinstance_filetransfer_port"`
+	MaxDownloadTotalBandwidth      uint64 `sq:"serverinstance_max_download_total_bandwidth"`
+	MaxUploadTotalBandwidth        uint64 `sq:"serverinstance_max_upload_total_bandwidth"`
+	GuestServerQueryGroup          uint   `sq:"serverinstance_guest_serverquery_group"`
+	ServerQueryFloodCommands       uint   `sq:"serverinstance_serverquery_flood_commands"`
+	ServerQueryFloodTime           uint   `sq:"serverinstance_serverquery_flood_time"`
+	ServerQueryBanTime             uint   `sq:"serverinstance_serverquery_ban_time"`
+	TemplateServerAdminGroup       uint   `sq:"serverinstance_template_serveradmin_group"`
+	TemplateServerDefaultGroup     uint   `sq:"serverinstance_template_serverdefault_group"`
+	TemplateChannelAdminGroup      uint   `sq:"serverinstance_template_channeladmin_group"`
+	TemplateChannelDefaultGroup    uint   `sq:"serverinstance_template_channeldefault_group"`
+	PermissionsVersion             uint   `sq:"serverinstance_permissions_version"`
+	PendingConnectionsPerIp        uint   `sq:"serverinstance_pending_connections_per_ip"`
+	ServerQueryMaxConnectionsPerIp uint   `sq:"serverinstance_serverquery_max_connections_per_ip"`
+
+	// Values as last read from (or written to) the server, used to find the changed properties
+	snapshot map[string]string
+}
+
+// Fields reported by instanceinfo that cannot be changed through InstanceEdit
+var readOnlyInstanceFields = map[string]bool{
+	"DatabaseVersion":    true,
+	"PermissionsVersion": true,
+}
+
+// The server software version, as returned by version
+type Version struct {
+	Version  string `sq:"version"`
+	Build    uint64 `sq:"build"`
+	Platform string `sq:"platform"`
+}
+
+// An address the server instance is bound to
+type Binding struct {
+	Ip string `sq:"ip"`
+}
+
+func NewHostInfo(hostInfoStr string) (*HostInfo, error) {
+	hostInfo := &HostInfo{}
+
+	err := decodeProperties(hostInfo, hostInfoStr)
+	if err != nil {
+		return hostInfo, err
+	}
+
+	return hostInfo, nil
+}
+
+func NewVersion(versionStr string) (*Version, error) {
+	version := &Version{}
+
+	err := decodeProperties(version, versionStr)
+	if err != nil {
+		return version, err
+	}
+
+	return version, nil
+}
+
+func NewInstance(instanceStr string) (*Instance, error) {
+	instance := &Instance{}
+
+	err := decodeProperties(instance, instanceStr)
+	if err != nil {
+		return instance, err
+	}
+	instance.snapshot = snapshotProperties(instance)
+
+	return instance, nil
+}
+
+// Lists the fields that were changed since the instance settings were read
+func (instance *Instance) Changed() []string {
+	return changedFields(instance, instance.snapshot)
+}
+
+// Builds the property string for the changed fields, rejecting changes to read-only fields. An empty string
+// means there is nothing to save.
+func (instance *Instance) editProperties() (string, error) {
+	changed := instance.Changed()
+	if len(changed) == 0 {
+		return "", nil
+	}
+
+	for _, fieldName := range changed {
+		if readOnlyInstanceFields[fieldName] {
+			return "", errors.New(fmt.Sprintf("Field %v is read-only on Instance", fieldName))
+		}
+	}
+
+	return encodeProperties(instance, changed)
+}
+
+// Reads the instance uptime and traffic counters
+func (ts3 *Connection) HostInfo() (*HostInfo, error) {
+	response, err := ts3.exec("hostinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHostInfo(response)
+}
+
+// Reads the instance wide settings
+func (ts3 *Connection) InstanceInfo() (*Instance, error) {
+	response, err := ts3.exec("instanceinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInstance(response)
+}
+
+// Saves the instance settings that changed since they were read
+func (ts3 *Connection) InstanceEdit(instance *Instance) error {
+	propertyString, err := instance.editProperties()
+	if err != nil || len(propertyString) == 0 {
+		return err
+	}
+
+	_, err = ts3.exec(fmt.Sprintf("instanceedit %v", propertyString))
+	if err != nil {
+		return err
+	}
+
+	instance.snapshot = snapshotProperties(instance)
+	return nil
+}
+
+// Reads the version, build and platform of the server instance
+func (ts3 *Connection) Version() (*Version, error) {
+	response, err := ts3.exec("version")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVersion(response)
+}
+
+// Lists the IP addresses the server instance is bound to
+func (ts3 *Connection) BindingList() ([]*Binding, error) {
+	response, err := ts3.exec("bindinglist")
+	if err != nil {
+		return make([]*Binding, 0), err
+	}
+
+	rawBindings := splitEntries(response)
+	bindings := make([]*Binding, len(rawBindings))
+	for i, rawBinding := range rawBindings {
+		bindings[i] = &Binding{}
+		err = decodeProperties(bindings[i], rawBinding)
+		if err != nil {
+			return bindings, err
+		}
+	}
+
+	return bindings, nil
+}
+
+// Stops the entire server instance
+func (ts3 *Connection) ServerProcessStop() error {
+	_, err := ts3.exec("serverprocessstop")
+	return err
+}
@@ -0,0 +1,99 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validInstanceInfoString = "serverinstance_database_version=26 serverinstance_filetransfer_port=30033 serverinstance_max_download_total_bandwidth=18446744073709551615 serverinstance_max_upload_total_bandwidth=18446744073709551615 serverinstance_guest_serverquery_group=1 serverinstance_serverquery_flood_commands=10 serverinstance_serverquery_flood_time=3 serverinstance_serverquery_ban_time=600 serverinstance_template_serveradmin_group=3 serverinstance_template_serverdefault_group=5 serverinstance_template_channeladmin_group=1 serverinstance_template_channeldefault_group=4 serverinstance_permissions_version=19"
+
+const validHostInfoString = "instance_uptime=1903 host_timestamp_utc=1259337246 virtualservers_running_total=2 virtualservers_total_maxclients=64 virtualservers_total_clients_online=7 virtualservers_total_channels_online=12 connection_filetransfer_bandwidth_sent=101 connection_filetransfer_bandwidth_received=102 connection_filetransfer_bytes_sent_total=103 connection_filetransfer_bytes_received_total=104 connection_packets_sent_total=39 connection_bytes_sent_total=2920 connection_packets_received_total=41 connection_bytes_received_total=2198 connection_bandwidth_sent_last_second_total=201 connection_bandwidth_sent_last_minute_total=202 connection_bandwidth_received_last_second_total=203 connection_bandwidth_received_last_minute_total=204"
+
+const validVersionString = "version=3.13.7 build=1655727713 platform=Linux"
+
+func TestNewHostInfo(t *testing.T) {
+	hostInfo, err := NewHostInfo(validHostInfoString)
+	if err != nil {
+		t.Errorf("NewHostInfo(\"%v\"): Errored out with %v", validHostInfoString, err)
+		return
+	}
+
+	// Every field is set to a distinct value so a mistyped tag shows up as a zero
+	expected := HostInfo{
+		Timestamp:                      1259337246,
+		Uptime:                         1903,
+		VirtualServersRunning:          2,
+		MaxClients:                     64,
+		ClientsOnline:                  7,
+		ChannelsOnline:                 12,
+		FileTransferBandwidthSent:      101,
+		FileTransferBandwidthReceived:  102,
+		FileTransferBytesSentTotal:     103,
+		FileTransferBytesReceivedTotal: 104,
+		PacketsSentTotal:               39,
+		BytesSentTotal:                 2920,
+		PacketsReceivedTotal:           41,
+		BytesReceivedTotal:             2198,
+		BandwidthSentLastSecond:        201,
+		BandwidthSentLastMinute:        202,
+		BandwidthReceivedLastSecond:    203,
+		BandwidthReceivedLastMinute:    204,
+	}
+	if *hostInfo != expected {
+		t.Errorf("NewHostInfo(\"%v\"): Parsed host info %v does not match source input", validHostInfoString, hostInfo)
+	}
+}
+
+func TestNewVersion(t *testing.T) {
+	version, err := NewVersion(validVersionString)
+	if err != nil {
+		t.Errorf("NewVersion(\"%v\"): Errored out with %v", validVersionString, err)
+		return
+	}
+
+	if version.Version != "3.13.7" || version.Build != 1655727713 || version.Platform != "Linux" {
+		t.Errorf("NewVersion(\"%v\"): Parsed version %v does not match source input", validVersionString, version)
+	}
+}
+
+func TestBindingList(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"bindinglist", "ip=0.0.0.0|ip=::\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	bindings, err := ts3.BindingList()
+	if err != nil {
+		t.Errorf("ts3.BindingList(): Errored out with %v", err)
+	} else if len(bindings) != 2 || bindings[0].Ip != "0.0.0.0" || bindings[1].Ip != "::" {
+		t.Errorf("ts3.BindingList(): Parsed %v", bindings)
+	}
+}
+
+func TestNewInstance(t *testing.T) {
+	instance, err := NewInstance(validInstanceInfoString)
+	if err != nil {
+		t.Errorf("NewInstance(\"%v\"): Errored out with %v", validInstanceInfoString, err)
+		return
+	}
+
+	if instance.DatabaseVersion != 26 || instance.FileTransferPort != 30033 || instance.MaxDownloadTotalBandwidth != 18446744073709551615 || instance.GuestServerQueryGroup != 1 || instance.ServerQueryFloodCommands != 10 || instance.TemplateServerDefaultGroup != 5 {
+		t.Errorf("NewInstance(\"%v\"): Parsed version %v does not match source input", validInstanceInfoString, instance)
+	}
+
+	if propertyString, err := instance.editProperties(); err != nil || propertyString != "" {
+		t.Errorf("instance.editProperties(): Expected nothing to send, received %v (%v)", propertyString, err)
+	}
+
+	instance.ServerQueryFloodCommands = 50
+	propertyString, err := instance.editProperties()
+	if err != nil {
+		t.Errorf("instance.editProperties(): Errored out with %v", err)
+	} else if propertyString != "serverinstance_serverquery_flood_commands=50" {
+		t.Errorf("instance.editProperties(): Returned %v", propertyString)
+	}
+
+	instance.DatabaseVersion = 27
+	if _, err = instance.editProperties(); err == nil {
+		t.Errorf("instance.editProperties(): Should have rejected the change to DatabaseVersion")
+	}
+}
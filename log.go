@@ -0,0 +1,189 @@
+package teamspeak
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout of the timestamp at the start of each log line
+const logTimestampLayout = "2006-01-02 15:04:05.999999"
+
+// A parsed line of the server log, Sid is 0 for instance level entries
+type LogEntry struct {
+	Timestamp time.Time
+	Level     string
+	Channel   string
+	Sid       uint
+	Message   string
+	Raw       string
+}
+
+// Options for LogView
+type LogViewOptions struct {
+	// Number of lines to return (1-100, 0 uses the server default)
+	Lines uint
+
+	// Return the newest lines first
+	Reverse bool
+
+	// Read the instance log rather than the log of the selected virtual server
+	Instance bool
+
+	// Position to continue reading from, as returned in LogPage.LastPos
+	BeginPos uint64
+}
+
+// A page of log lines as returned by logview, LastPos is where to continue reading (0 when the start of the log
+// has been reached)
+type LogPage struct {
+	Entries  []*LogEntry
+	LastPos  uint64
+	FileSize uint64
+}
+
+// Parses a log line of the form "timestamp|level|channel|sid|message"
+func NewLogEntry(line string) (*LogEntry, error) {
+	entry := &LogEntry{Raw: line}
+
+	fields := strings.SplitN(line, "|", 5)
+	if len(fields) != 5 {
+		entry.Message = strings.TrimSpace(line)
+		return entry, nil
+	}
+
+	timestamp, err := time.Parse(logTimestampLayout, strings.TrimSpace(fields[0]))
+	if err != nil {
+		return entry, err
+	}
+	entry.Timestamp = timestamp
+
+	entry.Level = strings.TrimSpace(fields[1])
+	entry.Channel = strings.TrimSpace(fields[2])
+
+	if sid := strings.TrimSpace(fields[3]); len(sid) > 0 {
+		parsed, err := strconv.ParseUint(sid, 10, 32)
+		if err != nil {
+			return entry, err
+		}
+		entry.Sid = uint(parsed)
+	}
+
+	entry.Message = strings.TrimSpace(fields[4])
+
+	return entry, nil
+}
+
+// Reads a page of the server log
+func (ts3 *Connection) LogView(options LogViewOptions) (*LogPage, error) {
+	command := "logview"
+	if options.Lines != 0 {
+		command += fmt.Sprintf(" lines=%d", options.Lines)
+	}
+	command += fmt.Sprintf(" reverse=%d instance=%d", boolFlag(options.Reverse), boolFlag(options.Instance))
+	if options.BeginPos != 0 {
+		command += fmt.Sprintf(" begin_pos=%d", options.BeginPos)
+	}
+
+	response, err := ts3.exec(command)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLogPage(response)
+}
+
+// Parses the response of logview, the positions are only reported with the first line
+func parseLogPage(response string) (*LogPage, error) {
+	page := &LogPage{Entries: make([]*LogEntry, 0)}
+
+	for _, rawLine := range splitEntries(response) {
+		line := struct {
+			LastPos  uint64 `sq:"last_pos"`
+			FileSize uint64 `sq:"file_size"`
+			Line     string `sq:"l"`
+		}{}
+
+		err := decodeProperties(&line, rawLine)
+		if err != nil {
+			return page, err
+		}
+
+		if line.LastPos != 0 || line.FileSize != 0 {
+			page.LastPos = line.LastPos
+			page.FileSize = line.FileSize
+		}
+
+		if len(line.Line) == 0 {
+			continue
+		}
+
+		entry, err := NewLogEntry(line.Line)
+		if err != nil {
+			return page, err
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+
+	return page, nil
+}
+
+// Writes a message of the level (see LogLevel*) to the log of the selected virtual server
+func (ts3 *Connection) LogAdd(level uint, message string) error {
+	_, err := ts3.exec(fmt.Sprintf("logadd loglevel=%d logmsg=%v", level, Escape(message)))
+	return err
+}
+
+// Walks backwards through the server log, newest entry first, fetching a page at a time
+type LogIterator struct {
+	ts3      *Connection
+	options  LogViewOptions
+	started  bool
+	buffered []*LogEntry
+	entry    *LogEntry
+	err      error
+}
+
+// Creates an iterator over the instance or selected virtual server log, fetching lines (1-100) per page
+func (ts3 *Connection) LogIterator(lines uint, instance bool) *LogIterator {
+	return &LogIterator{
+		ts3:     ts3,
+		options: LogViewOptions{Lines: lines, Reverse: true, Instance: instance},
+	}
+}
+
+// Advances to the next (older) entry, returning false once the start of the log is reached or an error occurred
+func (iterator *LogIterator) Next() bool {
+	for len(iterator.buffered) == 0 {
+		// A last_pos of 0 means there is nothing older left to read
+		if iterator.err != nil || (iterator.started && iterator.options.BeginPos == 0) {
+			return false
+		}
+
+		page, err := iterator.ts3.LogView(iterator.options)
+		if err != nil {
+			iterator.err = err
+			return false
+		}
+
+		iterator.started = true
+		iterator.options.BeginPos = page.LastPos
+		iterator.buffered = page.Entries
+	}
+
+	iterator.entry = iterator.buffered[0]
+	iterator.buffered = iterator.buffered[1:]
+
+	return true
+}
+
+// The entry Next advanced to
+func (iterator *LogIterator) Entry() *LogEntry {
+	return iterator.entry
+}
+
+// The error that stopped the iteration, if any
+func (iterator *LogIterator) Err() error {
+	return iterator.err
+}
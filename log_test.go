@@ -0,0 +1,51 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validLogPageString = "last_pos=1024 file_size=4096 l=2014-05-15\\s12:00:01.654321\\pINFO\\s\\s\\s\\s\\pVirtualServerBase\\p\\s\\s1\\pclient\\sconnected\\s'Alice'(id:2)|l=2014-05-15\\s11:59:00.000001\\pWARNING\\s\\pServerMain\\s\\s\\s\\s\\s\\s\\s\\p\\s\\s\\s\\pinstance\\snotice"
+
+func TestParseLogPage(t *testing.T) {
+	page, err := parseLogPage(validLogPageString)
+	if err != nil {
+		t.Errorf("parseLogPage(\"%v\"): Errored out with %v", validLogPageString, err)
+		return
+	}
+
+	if page.LastPos != 1024 || page.FileSize != 4096 || len(page.Entries) != 2 {
+		t.Errorf("parseLogPage(\"%v\"): Parsed version %v does not match source input", validLogPageString, page)
+		return
+	}
+
+	entry := page.Entries[0]
+	if entry.Timestamp.Format("2006-01-02 15:04:05.000000") != "2014-05-15 12:00:01.654321" || entry.Level != "INFO" || entry.Channel != "VirtualServerBase" || entry.Sid != 1 || entry.Message != "client connected 'Alice'(id:2)" {
+		t.Errorf("parseLogPage(\"%v\"): Parsed entry %v does not match source input", validLogPageString, entry)
+	}
+
+	entry = page.Entries[1]
+	if entry.Level != "WARNING" || entry.Channel != "ServerMain" || entry.Sid != 0 || entry.Message != "instance notice" {
+		t.Errorf("parseLogPage(\"%v\"): Parsed entry %v does not match source input", validLogPageString, entry)
+	}
+}
+
+func TestLogIterator(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"logview lines=2 reverse=1 instance=0", "last_pos=100 file_size=300 l=2014-05-15\\s12:00:03.000000\\pINFO\\pServer\\p\\s\\s1\\pthird|l=2014-05-15\\s12:00:02.000000\\pINFO\\pServer\\p\\s\\s1\\psecond\n\rerror id=0 msg=ok"},
+		{"logview lines=2 reverse=1 instance=0 begin_pos=100", "last_pos=0 file_size=300 l=2014-05-15\\s12:00:01.000000\\pINFO\\pServer\\p\\s\\s1\\pfirst\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	messages := make([]string, 0)
+	iterator := ts3.LogIterator(2, false)
+	for iterator.Next() {
+		messages = append(messages, iterator.Entry().Message)
+	}
+
+	if iterator.Err() != nil {
+		t.Errorf("iterator.Next(): Errored out with %v", iterator.Err())
+	}
+	if len(messages) != 3 || messages[0] != "third" || messages[2] != "first" {
+		t.Errorf("iterator.Next(): Expected [third second first], received %v", messages)
+	}
+}
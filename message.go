@@ -0,0 +1,83 @@
+package teamspeak
+
+import (
+	"fmt"
+	"time"
+)
+
+// A message in the query client's offline mailbox
+type OfflineMessage struct {
+	Id        uint   `sq:"msgid"`
+	SenderUid string `sq:"cluid"`
+	Subject   string `sq:"subject"`
+	Message   string `sq:"message"`
+	Timestamp int64  `sq:"timestamp"`
+	FlagRead  bool   `sq:"flag_read"`
+}
+
+func NewOfflineMessage(messageStr string) (*OfflineMessage, error) {
+	message := &OfflineMessage{}
+
+	err := decodeProperties(message, messageStr)
+	if err != nil {
+		return message, err
+	}
+
+	return message, nil
+}
+
+// When the message was sent
+func (message *OfflineMessage) SentAt() time.Time {
+	return time.Unix(message.Timestamp, 0)
+}
+
+// Lists the messages in the mailbox, the message bodies are only returned by MessageGet
+func (ts3 *Connection) MessageList() ([]*OfflineMessage, error) {
+	response, err := ts3.exec("messagelist")
+	if isEmptyResult(err) {
+		return make([]*OfflineMessage, 0), nil
+	}
+	if err != nil {
+		return make([]*OfflineMessage, 0), err
+	}
+
+	rawMessages := splitEntries(response)
+	messages := make([]*OfflineMessage, len(rawMessages))
+	for i, rawMessage := range rawMessages {
+		message, err := NewOfflineMessage(rawMessage)
+		if err != nil {
+			return messages, err
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+// Leaves a message for the client (by unique id), it is delivered the next time they connect
+func (ts3 *Connection) MessageAdd(cluid, subject, message string) error {
+	_, err := ts3.exec(fmt.Sprintf("messageadd cluid=%v subject=%v message=%v", Escape(cluid), Escape(subject), Escape(message)))
+	return err
+}
+
+// Reads a message including its body
+func (ts3 *Connection) MessageGet(msgid uint) (*OfflineMessage, error) {
+	response, err := ts3.exec(fmt.Sprintf("messageget msgid=%d", msgid))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOfflineMessage(response)
+}
+
+// Marks the message as read or unread
+func (ts3 *Connection) MessageUpdateFlag(msgid uint, read bool) error {
+	_, err := ts3.exec(fmt.Sprintf("messageupdateflag msgid=%d flag=%d", msgid, boolFlag(read)))
+	return err
+}
+
+// Deletes the message from the mailbox
+func (ts3 *Connection) MessageDel(msgid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("messagedel msgid=%d", msgid))
+	return err
+}
@@ -0,0 +1,19 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validOfflineMessageString = "msgid=4 cluid=xGokGrDa2t2tG4S7s\\/2BIlUMtbw= subject=Welcome message=Read\\sthe\\srules\\nplease timestamp=1400000000 flag_read=0"
+
+func TestNewOfflineMessage(t *testing.T) {
+	message, err := NewOfflineMessage(validOfflineMessageString)
+	if err != nil {
+		t.Errorf("NewOfflineMessage(\"%v\"): Errored out with %v", validOfflineMessageString, err)
+		return
+	}
+
+	if message.Id != 4 || message.SenderUid != "xGokGrDa2t2tG4S7s/2BIlUMtbw=" || message.Subject != "Welcome" || message.Message != "Read the rules\nplease" || message.FlagRead || message.SentAt().Unix() != 1400000000 {
+		t.Errorf("NewOfflineMessage(\"%v\"): Parsed version %v does not match source input", validOfflineMessageString, message)
+	}
+}
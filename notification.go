@@ -0,0 +1,136 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Event sources for servernotifyregister
+const (
+	NotifyEventServer      = "server"
+	NotifyEventChannel     = "channel"
+	NotifyEventTextServer  = "textserver"
+	NotifyEventTextChannel = "textchannel"
+	NotifyEventTextPrivate = "textprivate"
+)
+
+// An event pushed by the server, e.g. notifytextmessage
+type Notification struct {
+	Type string
+	Data string
+}
+
+// A text message sent to the query client (privately), its channel or its server
+type TextMessageEvent struct {
+	TargetMode  uint   `sq:"targetmode"`
+	Message     string `sq:"msg"`
+	Target      uint   `sq:"target"`
+	InvokerId   uint   `sq:"invokerid"`
+	InvokerName string `sq:"invokername"`
+	InvokerUid  string `sq:"invokeruid"`
+}
+
+// Splits a raw notification line into its type and property string
+func newNotification(line string) *Notification {
+	parts := strings.SplitN(line, " ", 2)
+
+	notification := &Notification{Type: parts[0]}
+	if len(parts) == 2 {
+		notification.Data = parts[1]
+	}
+
+	return notification
+}
+
+// Parses the notification as a text message, Type must be notifytextmessage
+func (notification *Notification) TextMessage() (*TextMessageEvent, error) {
+	event := &TextMessageEvent{}
+
+	if notification.Type != "notifytextmessage" {
+		return event, errors.New(fmt.Sprintf("Notification %v is not a text message", notification.Type))
+	}
+
+	err := decodeProperties(event, notification.Data)
+	return event, err
+}
+
+// Subscribes to an event source (see NotifyEvent*), id selects the channel for NotifyEventChannel (0 for all)
+func (ts3 *Connection) ServerNotifyRegister(event string, id uint) error {
+	command := fmt.Sprintf("servernotifyregister event=%v", event)
+	if event == NotifyEventChannel {
+		command += fmt.Sprintf(" id=%d", id)
+	}
+
+	_, err := ts3.exec(command)
+	return err
+}
+
+// Unsubscribes from every event source
+func (ts3 *Connection) ServerNotifyUnregister() error {
+	_, err := ts3.exec("servernotifyunregister")
+	return err
+}
+
+// Returns the next event pushed by the server, blocking until one arrives. Events received while waiting for a
+// command response are returned first.
+func (ts3 *Connection) ReadNotification() (*Notification, error) {
+	for len(ts3.notifications) == 0 {
+		line, err := ts3.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(line, "notify") {
+			ts3.notifications = append(ts3.notifications, newNotification(line))
+		}
+	}
+
+	notification := ts3.notifications[0]
+	ts3.notifications = ts3.notifications[1:]
+
+	if ts3.Debug {
+		fmt.Println(fmt.Sprintf("NOTIFY: %v %v", notification.Type, notification.Data))
+	}
+
+	return notification, nil
+}
+
+// Waits up to timeout for a notification (or anything else) from the server, reporting false when nothing arrived.
+// Only line breaks are consumed (replies end in "\n\r", leaving a "\r" behind) so a line arriving in the meantime
+// is left intact for ReadNotification.
+func (ts3 *Connection) waitNotification(timeout time.Duration) (bool, error) {
+	if len(ts3.notifications) > 0 {
+		return true, nil
+	}
+
+	for ts3.rw.Reader.Buffered() > 0 {
+		next, _ := ts3.rw.Peek(1)
+		if next[0] != '\r' && next[0] != '\n' {
+			return true, nil
+		}
+		ts3.rw.Discard(1)
+	}
+
+	err := ts3.conn.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return false, err
+	}
+
+	_, err = ts3.rw.Peek(1)
+	if clearErr := ts3.conn.SetReadDeadline(time.Time{}); err == nil {
+		err = clearErr
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Something arrived, check it is more than line breaks
+	return ts3.waitNotification(timeout)
+}
@@ -0,0 +1,30 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+func TestReadNotification(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"servernotifyregister event=textprivate", "notifytextmessage targetmode=1 msg=hi target=9 invokerid=3 invokername=Alice invokeruid=abc=\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	// The event arrives before the command completes and is held until asked for
+	if err := ts3.ServerNotifyRegister(NotifyEventTextPrivate, 0); err != nil {
+		t.Errorf("ts3.ServerNotifyRegister(): Errored out with %v", err)
+	}
+
+	notification, err := ts3.ReadNotification()
+	if err != nil {
+		t.Errorf("ts3.ReadNotification(): Errored out with %v", err)
+		return
+	}
+
+	event, err := notification.TextMessage()
+	if err != nil {
+		t.Errorf("notification.TextMessage(): Errored out with %v", err)
+	} else if event.Message != "hi" || event.InvokerId != 3 || event.InvokerName != "Alice" || event.TargetMode != TextMessageTargetClient {
+		t.Errorf("notification.TextMessage(): Parsed version %v does not match source input", event)
+	}
+}
@@ -0,0 +1,279 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A single permission assignment, the permission is identified either by Id (permid) or Name (permsid)
+type Permission struct {
+	Id      uint   `sq:"permid"`
+	Name    string `sq:"permsid"`
+	Value   int    `sq:"permvalue"`
+	Negated bool   `sq:"permnegated"`
+	Skip    bool   `sq:"permskip"`
+}
+
+func NewPermission(permissionStr string) (*Permission, error) {
+	permission := &Permission{}
+
+	err := decodeProperties(permission, permissionStr)
+	if err != nil {
+		return permission, err
+	}
+
+	return permission, nil
+}
+
+// Identifies the permission in a command, preferring the name when one is present
+func (perm *Permission) identifier() string {
+	if len(perm.Name) > 0 {
+		return fmt.Sprintf("permsid=%v", Escape(perm.Name))
+	}
+
+	return fmt.Sprintf("permid=%d", perm.Id)
+}
+
+// Parses the response of one of the *permlist commands
+func parsePermissions(response string) ([]*Permission, error) {
+	rawPermissions := splitEntries(response)
+	permissions := make([]*Permission, len(rawPermissions))
+
+	for i, rawPermission := range rawPermissions {
+		permission, err := NewPermission(rawPermission)
+		if err != nil {
+			return permissions, err
+		}
+		permissions[i] = permission
+	}
+
+	return permissions, nil
+}
+
+// Reads a permission list, an empty result set from the server is an empty list rather than an error
+func (ts3 *Connection) permList(command string, permsid bool) ([]*Permission, error) {
+	if permsid {
+		command += " -permsid"
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*Permission, 0), nil
+	}
+	if err != nil {
+		return make([]*Permission, 0), err
+	}
+
+	return parsePermissions(response)
+}
+
+// Sends command with every permission appended as a piped entry, format renders the entry for a single permission
+func (ts3 *Connection) permEdit(command string, perms []*Permission, format func(*Permission) string) error {
+	if len(perms) == 0 {
+		return errors.New("No permissions listed")
+	}
+
+	entries := make([]string, len(perms))
+	for i, perm := range perms {
+		entries[i] = format(perm)
+	}
+
+	_, err := ts3.exec(fmt.Sprintf("%v %v", command, strings.Join(entries, "|")))
+	return err
+}
+
+// Formats a permission with only its identifier, as used by the *delperm commands
+func permIdentifier(perm *Permission) string {
+	return perm.identifier()
+}
+
+// Formats a permission with its identifier and value
+func permValue(perm *Permission) string {
+	return fmt.Sprintf("%v permvalue=%d", perm.identifier(), perm.Value)
+}
+
+// Formats a permission with its identifier, value and the negated/skip flags
+func permFull(perm *Permission) string {
+	return fmt.Sprintf("%v permvalue=%d permnegated=%d permskip=%d", perm.identifier(), perm.Value, boolFlag(perm.Negated), boolFlag(perm.Skip))
+}
+
+// Formats a permission with its identifier, value and the skip flag
+func permSkip(perm *Permission) string {
+	return fmt.Sprintf("%v permvalue=%d permskip=%d", perm.identifier(), perm.Value, boolFlag(perm.Skip))
+}
+
+// An entry of the server's permission catalog
+type PermissionInfo struct {
+	Id          uint   `sq:"permid"`
+	Name        string `sq:"permname"`
+	Description string `sq:"permdesc"`
+}
+
+// A location where a permission is assigned, Type (see PermissionType*) determines the meaning of Id1 and Id2
+// (e.g. sgid for server groups, cid and cldbid for channel clients)
+type PermissionAssignment struct {
+	Type   uint `sq:"t"`
+	Id1    uint `sq:"id1"`
+	Id2    uint `sq:"id2"`
+	PermId uint `sq:"p"`
+}
+
+// Translates permission names to ids and back, loaded from permissionlist on first use
+type permissionCatalog struct {
+	ids   map[string]uint
+	names map[uint]string
+}
+
+// Reads the catalog of permissions known to the server
+func (ts3 *Connection) PermissionList() ([]*PermissionInfo, error) {
+	response, err := ts3.exec("permissionlist")
+	if err != nil {
+		return make([]*PermissionInfo, 0), err
+	}
+
+	rawPermissions := splitEntries(response)
+	permissions := make([]*PermissionInfo, 0, len(rawPermissions))
+	for _, rawPermission := range rawPermissions {
+		permission := &PermissionInfo{}
+		err = decodeProperties(permission, rawPermission)
+		if err != nil {
+			return permissions, err
+		}
+
+		// Newer servers interleave group markers (group_id_end=...) with the permissions, skip those
+		if len(permission.Name) > 0 {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions, nil
+}
+
+// Looks up the ids of the named permissions
+func (ts3 *Connection) PermIdGetByName(names []string) (map[string]uint, error) {
+	ids := make(map[string]uint)
+	if len(names) == 0 {
+		return ids, errors.New("No permissions listed")
+	}
+
+	entries := make([]string, len(names))
+	for i, name := range names {
+		entries[i] = fmt.Sprintf("permsid=%v", Escape(name))
+	}
+
+	response, err := ts3.exec(fmt.Sprintf("permidgetbyname %v", strings.Join(entries, "|")))
+	if err != nil {
+		return ids, err
+	}
+
+	permissions, err := parsePermissions(response)
+	for _, permission := range permissions {
+		if permission != nil {
+			ids[permission.Name] = permission.Id
+		}
+	}
+
+	return ids, err
+}
+
+// Lists every server group, client, channel, channel group and channel client assignment of the permission
+func (ts3 *Connection) PermFind(perm *Permission) ([]*PermissionAssignment, error) {
+	response, err := ts3.exec(fmt.Sprintf("permfind %v", perm.identifier()))
+	if isEmptyResult(err) {
+		return make([]*PermissionAssignment, 0), nil
+	}
+	if err != nil {
+		return make([]*PermissionAssignment, 0), err
+	}
+
+	rawAssignments := splitEntries(response)
+	assignments := make([]*PermissionAssignment, len(rawAssignments))
+	for i, rawAssignment := range rawAssignments {
+		assignments[i] = &PermissionAssignment{}
+		err = decodeProperties(assignments[i], rawAssignment)
+		if err != nil {
+			return assignments, err
+		}
+	}
+
+	return assignments, nil
+}
+
+// Returns the cached permission catalog, loading it from the server on first use
+func (ts3 *Connection) permissionCatalog() (*permissionCatalog, error) {
+	if ts3.permissions != nil {
+		return ts3.permissions, nil
+	}
+
+	permissions, err := ts3.PermissionList()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &permissionCatalog{
+		ids:   make(map[string]uint, len(permissions)),
+		names: make(map[uint]string, len(permissions)),
+	}
+	for _, permission := range permissions {
+		catalog.ids[permission.Name] = permission.Id
+		catalog.names[permission.Id] = permission.Name
+	}
+
+	ts3.permissions = catalog
+	return catalog, nil
+}
+
+// Translates a permission name into its id using the cached catalog
+func (ts3 *Connection) PermissionId(name string) (uint, error) {
+	catalog, err := ts3.permissionCatalog()
+	if err != nil {
+		return 0, err
+	}
+
+	id, found := catalog.ids[name]
+	if !found {
+		return 0, errors.New(fmt.Sprintf("Permission %v not found", name))
+	}
+
+	return id, nil
+}
+
+// Translates a permission id into its name using the cached catalog
+func (ts3 *Connection) PermissionName(id uint) (string, error) {
+	catalog, err := ts3.permissionCatalog()
+	if err != nil {
+		return "", err
+	}
+
+	name, found := catalog.names[id]
+	if !found {
+		return "", errors.New(fmt.Sprintf("Permission %d not found", id))
+	}
+
+	return name, nil
+}
+
+// Fills in whichever of Id or Name is missing on each permission using the cached catalog
+func (ts3 *Connection) ResolvePermissions(perms []*Permission) error {
+	for _, perm := range perms {
+		var err error
+
+		if len(perm.Name) > 0 {
+			perm.Id, err = ts3.PermissionId(perm.Name)
+		} else {
+			perm.Name, err = ts3.PermissionName(perm.Id)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Drops the cached permission catalog, the next lookup reloads it from the server
+func (ts3 *Connection) ClearPermissionCache() {
+	ts3.permissions = nil
+}
@@ -0,0 +1,83 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validPermissionListString = "cid=2 permid=8470 permvalue=1 permnegated=0 permskip=0|permid=8475 permvalue=-1 permnegated=1 permskip=1"
+const validPermissionListStringWithNames = "cid=2 permsid=i_icon_id permvalue=100 permnegated=0 permskip=0"
+
+func TestParsePermissions(t *testing.T) {
+	permissions, err := parsePermissions(validPermissionListString)
+	if err != nil {
+		t.Errorf("parsePermissions(\"%v\"): Errored out with %v", validPermissionListString, err)
+	} else if len(permissions) != 2 {
+		t.Errorf("parsePermissions(\"%v\"): Expected 2 permissions, received %d", validPermissionListString, len(permissions))
+	} else {
+		if permissions[0].Id != 8470 || permissions[0].Value != 1 || permissions[0].Negated || permissions[0].Skip {
+			t.Errorf("parsePermissions(\"%v\"): Parsed version %v does not match source input", validPermissionListString, permissions[0])
+		}
+		if permissions[1].Id != 8475 || permissions[1].Value != -1 || !permissions[1].Negated || !permissions[1].Skip {
+			t.Errorf("parsePermissions(\"%v\"): Parsed version %v does not match source input", validPermissionListString, permissions[1])
+		}
+	}
+
+	permissions, err = parsePermissions(validPermissionListStringWithNames)
+	if err != nil {
+		t.Errorf("parsePermissions(\"%v\"): Errored out with %v", validPermissionListStringWithNames, err)
+	} else if len(permissions) != 1 || permissions[0].Name != "i_icon_id" || permissions[0].Value != 100 {
+		t.Errorf("parsePermissions(\"%v\"): Parsed version %v does not match source input", validPermissionListStringWithNames, permissions)
+	}
+
+	permissions, err = parsePermissions("")
+	if err != nil || len(permissions) != 0 {
+		t.Errorf("parsePermissions(\"\"): Expected no permissions, received %v (%v)", permissions, err)
+	}
+}
+
+func TestPermissionFormat(t *testing.T) {
+	byId := &Permission{Id: 8470, Value: 75}
+	if formatted := permValue(byId); formatted != "permid=8470 permvalue=75" {
+		t.Errorf("permValue(%v): Returned %v", byId, formatted)
+	}
+
+	byName := &Permission{Id: 8470, Name: "i_channel_needed_join_power", Value: 75}
+	if formatted := permValue(byName); formatted != "permsid=i_channel_needed_join_power permvalue=75" {
+		t.Errorf("permValue(%v): Returned %v", byName, formatted)
+	}
+
+	flagged := &Permission{Name: "b_client_ignore_antiflood", Value: 1, Negated: true, Skip: true}
+	if formatted := permFull(flagged); formatted != "permsid=b_client_ignore_antiflood permvalue=1 permnegated=1 permskip=1" {
+		t.Errorf("permFull(%v): Returned %v", flagged, formatted)
+	}
+
+	if formatted := permSkip(flagged); formatted != "permsid=b_client_ignore_antiflood permvalue=1 permskip=1" {
+		t.Errorf("permSkip(%v): Returned %v", flagged, formatted)
+	}
+
+	if formatted := permIdentifier(byName); formatted != "permsid=i_channel_needed_join_power" {
+		t.Errorf("permIdentifier(%v): Returned %v", byName, formatted)
+	}
+}
+
+func TestResolvePermissions(t *testing.T) {
+	// Prime the cache so no server round trip is needed
+	ts3 := &Connection{}
+	ts3.permissions = &permissionCatalog{
+		ids:   map[string]uint{"i_icon_id": 8470, "i_client_talk_power": 8475},
+		names: map[uint]string{8470: "i_icon_id", 8475: "i_client_talk_power"},
+	}
+
+	perms := []*Permission{&Permission{Name: "i_icon_id"}, &Permission{Id: 8475}}
+	err := ts3.ResolvePermissions(perms)
+	if err != nil {
+		t.Errorf("ts3.ResolvePermissions(%v): Errored out with %v", perms, err)
+	} else if perms[0].Id != 8470 || perms[1].Name != "i_client_talk_power" {
+		t.Errorf("ts3.ResolvePermissions(%v): Did not fill in the missing identifiers", perms)
+	}
+
+	unknown := []*Permission{&Permission{Name: "i_not_a_permission"}}
+	if err = ts3.ResolvePermissions(unknown); err == nil {
+		t.Errorf("ts3.ResolvePermissions(%v): Should have thrown an error", unknown)
+	}
+}
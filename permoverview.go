@@ -0,0 +1,159 @@
+package teamspeak
+
+import (
+	"fmt"
+)
+
+// A single contribution to a client's effective permission, as reported by permoverview. Type (see
+// PermissionType*) determines the meaning of Id1 and Id2.
+type PermissionSource struct {
+	Type    uint `sq:"t"`
+	Id1     uint `sq:"id1"`
+	Id2     uint `sq:"id2"`
+	PermId  uint `sq:"p"`
+	Value   int  `sq:"v"`
+	Negated bool `sq:"n"`
+	Skip    bool `sq:"s"`
+}
+
+// Why a client has (or lacks) a permission in a channel
+type PermissionExplanation struct {
+	PermId  uint
+	Sources []*PermissionSource
+
+	// The source whose value is in effect, nil when the permission is not assigned anywhere
+	Winner *PermissionSource
+}
+
+// Reports whether the permission is assigned to the client in the channel at all
+func (explanation *PermissionExplanation) Granted() bool {
+	return explanation.Winner != nil
+}
+
+// The effective value of the permission, 0 when it is not assigned
+func (explanation *PermissionExplanation) Value() int {
+	if explanation.Winner == nil {
+		return 0
+	}
+
+	return explanation.Winner.Value
+}
+
+// Lists every assignment contributing to the client's permissions in the channel, a permId of 0 lists all permissions
+func (ts3 *Connection) PermOverview(cid, cldbid, permId uint) ([]*PermissionSource, error) {
+	response, err := ts3.exec(fmt.Sprintf("permoverview cid=%d cldbid=%d permid=%d", cid, cldbid, permId))
+	if isEmptyResult(err) {
+		return make([]*PermissionSource, 0), nil
+	}
+	if err != nil {
+		return make([]*PermissionSource, 0), err
+	}
+
+	rawSources := splitEntries(response)
+	sources := make([]*PermissionSource, len(rawSources))
+	for i, rawSource := range rawSources {
+		sources[i] = &PermissionSource{}
+		err = decodeProperties(sources[i], rawSource)
+		if err != nil {
+			return sources, err
+		}
+	}
+
+	return sources, nil
+}
+
+// Reads the value of the permission for the query client itself
+func (ts3 *Connection) PermGet(perm *Permission) (*Permission, error) {
+	response, err := ts3.exec(fmt.Sprintf("permget %v", perm.identifier()))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPermission(response)
+}
+
+// Explains where the client's (by database id) effective value of the permission in the channel comes from
+func (ts3 *Connection) ExplainPermission(cid, cldbid uint, perm *Permission) (*PermissionExplanation, error) {
+	permId := perm.Id
+	if len(perm.Name) > 0 {
+		var err error
+
+		permId, err = ts3.PermissionId(perm.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sources, err := ts3.PermOverview(cid, cldbid, permId)
+	if err != nil {
+		return nil, err
+	}
+
+	return explainPermission(permId, sources), nil
+}
+
+// Applies the server's permission hierarchy to the sources of a single permission. Server groups are combined
+// first (the highest value wins, unless a group negates the permission in which case the lowest negated value
+// wins), then overridden by client permissions. Channel and channel group permissions override those unless
+// the skip flag was set, and channel client permissions override everything.
+func explainPermission(permId uint, sources []*PermissionSource) *PermissionExplanation {
+	explanation := &PermissionExplanation{PermId: permId, Sources: make([]*PermissionSource, 0)}
+
+	var serverGroup, client, channel, channelGroup, channelClient *PermissionSource
+	skip := false
+	negated := false
+
+	for _, source := range sources {
+		if source.PermId != permId {
+			continue
+		}
+		explanation.Sources = append(explanation.Sources, source)
+
+		switch source.Type {
+		case PermissionTypeServerGroup:
+			skip = skip || source.Skip
+
+			switch {
+			case serverGroup == nil:
+				serverGroup = source
+			case source.Negated && (!negated || source.Value < serverGroup.Value):
+				serverGroup = source
+			case !source.Negated && !negated && source.Value > serverGroup.Value:
+				serverGroup = source
+			}
+			negated = negated || source.Negated
+
+		case PermissionTypeClient:
+			skip = skip || source.Skip
+			client = source
+
+		case PermissionTypeChannel:
+			channel = source
+
+		case PermissionTypeChannelGroup:
+			channelGroup = source
+
+		case PermissionTypeChannelClient:
+			channelClient = source
+		}
+	}
+
+	// Walk the hierarchy from the weakest to the strongest source
+	for _, source := range []*PermissionSource{serverGroup, client} {
+		if source != nil {
+			explanation.Winner = source
+		}
+	}
+	if !skip {
+		for _, source := range []*PermissionSource{channel, channelGroup} {
+			if source != nil {
+				explanation.Winner = source
+			}
+		}
+	}
+	if channelClient != nil {
+		explanation.Winner = channelClient
+	}
+
+	return explanation
+}
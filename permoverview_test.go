@@ -0,0 +1,59 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+func TestExplainPermission(t *testing.T) {
+	// Highest server group value wins
+	sources := []*PermissionSource{
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 6, PermId: 10, Value: 50},
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 8, PermId: 10, Value: 75},
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 8, PermId: 11, Value: 100},
+	}
+	explanation := explainPermission(10, sources)
+	if len(explanation.Sources) != 2 || explanation.Winner != sources[1] || explanation.Value() != 75 {
+		t.Errorf("explainPermission(10, %v): Expected server group 8 to win, received %v", sources, explanation.Winner)
+	}
+
+	// Negated server groups take the lowest negated value
+	sources = []*PermissionSource{
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 6, PermId: 10, Value: 50, Negated: true},
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 8, PermId: 10, Value: 75},
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 9, PermId: 10, Value: 20},
+	}
+	explanation = explainPermission(10, sources)
+	if explanation.Winner != sources[0] {
+		t.Errorf("explainPermission(10, %v): Expected negated server group 6 to win, received %v", sources, explanation.Winner)
+	}
+
+	// Channel groups override server groups and client permissions
+	sources = []*PermissionSource{
+		&PermissionSource{Type: PermissionTypeServerGroup, Id1: 6, PermId: 10, Value: 50},
+		&PermissionSource{Type: PermissionTypeClient, Id1: 2, PermId: 10, Value: 60},
+		&PermissionSource{Type: PermissionTypeChannelGroup, Id1: 5, Id2: 1, PermId: 10, Value: 10},
+	}
+	explanation = explainPermission(10, sources)
+	if explanation.Winner != sources[2] {
+		t.Errorf("explainPermission(10, %v): Expected channel group to win, received %v", sources, explanation.Winner)
+	}
+
+	// Skip keeps channel and channel group permissions from overriding, channel client permissions still apply
+	sources[1].Skip = true
+	explanation = explainPermission(10, sources)
+	if explanation.Winner != sources[1] {
+		t.Errorf("explainPermission(10, %v): Expected skipping client permission to win, received %v", sources, explanation.Winner)
+	}
+
+	sources = append(sources, &PermissionSource{Type: PermissionTypeChannelClient, Id1: 1, Id2: 2, PermId: 10, Value: 5})
+	explanation = explainPermission(10, sources)
+	if explanation.Winner != sources[3] {
+		t.Errorf("explainPermission(10, %v): Expected channel client permission to win, received %v", sources, explanation.Winner)
+	}
+
+	// Nothing assigned
+	explanation = explainPermission(12, sources)
+	if explanation.Granted() || explanation.Value() != 0 {
+		t.Errorf("explainPermission(12, %v): Expected no winner, received %v", sources, explanation.Winner)
+	}
+}
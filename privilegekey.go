@@ -0,0 +1,111 @@
+package teamspeak
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A privilege key (token) granting a server group, or a channel group in a channel, to the client using it.
+// For server group keys Id1 is the sgid, for channel group keys Id1 is the cgid and Id2 the cid.
+type PrivilegeKey struct {
+	Token       string `sq:"token"`
+	Type        uint   `sq:"token_type"`
+	Id1         uint   `sq:"token_id1"`
+	Id2         uint   `sq:"token_id2"`
+	Created     int64  `sq:"token_created"`
+	Description string `sq:"token_description"`
+	CustomSet   string `sq:"token_customset"`
+}
+
+func NewPrivilegeKey(keyStr string) (*PrivilegeKey, error) {
+	key := &PrivilegeKey{}
+
+	err := decodeProperties(key, keyStr)
+	if err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
+
+// When the key was created
+func (key *PrivilegeKey) CreatedAt() time.Time {
+	return time.Unix(key.Created, 0)
+}
+
+// Lists the unused privilege keys of the selected virtual server
+func (ts3 *Connection) PrivilegeKeyList() ([]*PrivilegeKey, error) {
+	response, err := ts3.exec("privilegekeylist")
+	if isEmptyResult(err) {
+		return make([]*PrivilegeKey, 0), nil
+	}
+	if err != nil {
+		return make([]*PrivilegeKey, 0), err
+	}
+
+	rawKeys := splitEntries(response)
+	keys := make([]*PrivilegeKey, len(rawKeys))
+	for i, rawKey := range rawKeys {
+		key, err := NewPrivilegeKey(rawKey)
+		if err != nil {
+			return keys, err
+		}
+		keys[i] = key
+	}
+
+	return keys, nil
+}
+
+// Creates a privilege key of the type (see TokenType*) and returns the token. id1 and id2 are the sgid and 0 for
+// server group keys, the cgid and cid for channel group keys. The custom set is stored on the client using the key.
+func (ts3 *Connection) PrivilegeKeyAdd(tokenType, id1, id2 uint, description string, customSet map[string]string) (string, error) {
+	command := fmt.Sprintf("privilegekeyadd tokentype=%d tokenid1=%d tokenid2=%d", tokenType, id1, id2)
+	if len(description) > 0 {
+		command += fmt.Sprintf(" tokendescription=%v", Escape(description))
+	}
+	if len(customSet) > 0 {
+		command += fmt.Sprintf(" tokencustomset=%v", Escape(encodeCustomSet(customSet)))
+	}
+
+	response, err := ts3.exec(command)
+	if err != nil {
+		return "", err
+	}
+
+	created := struct {
+		Token string `sq:"token"`
+	}{}
+	err = decodeProperties(&created, response)
+
+	return created.Token, err
+}
+
+// Deletes the privilege key
+func (ts3 *Connection) PrivilegeKeyDelete(token string) error {
+	_, err := ts3.exec(fmt.Sprintf("privilegekeydelete token=%v", Escape(token)))
+	return err
+}
+
+// Uses the privilege key, granting its group to the query client
+func (ts3 *Connection) PrivilegeKeyUse(token string) error {
+	_, err := ts3.exec(fmt.Sprintf("privilegekeyuse token=%v", Escape(token)))
+	return err
+}
+
+// Formats custom client properties as "ident=... value=...|ident=... value=..." (sorted by ident)
+func encodeCustomSet(customSet map[string]string) string {
+	idents := make([]string, 0, len(customSet))
+	for ident := range customSet {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+
+	entries := make([]string, len(idents))
+	for i, ident := range idents {
+		entries[i] = fmt.Sprintf("ident=%v value=%v", Escape(ident), Escape(customSet[ident]))
+	}
+
+	return strings.Join(entries, "|")
+}
@@ -0,0 +1,33 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validPrivilegeKeyString = "token=zhPQhMgq8GbWKsFrbSQT4yrd7nk4HR2A0eyy+1Bc token_type=1 token_id1=5 token_id2=12 token_created=1400000000 token_description=New\\smember"
+
+func TestNewPrivilegeKey(t *testing.T) {
+	key, err := NewPrivilegeKey(validPrivilegeKeyString)
+	if err != nil {
+		t.Errorf("NewPrivilegeKey(\"%v\"): Errored out with %v", validPrivilegeKeyString, err)
+		return
+	}
+
+	if key.Token != "zhPQhMgq8GbWKsFrbSQT4yrd7nk4HR2A0eyy+1Bc" || key.Type != TokenTypeChannelGroup || key.Id1 != 5 || key.Id2 != 12 || key.Description != "New member" || key.CreatedAt().Unix() != 1400000000 {
+		t.Errorf("NewPrivilegeKey(\"%v\"): Parsed version %v does not match source input", validPrivilegeKeyString, key)
+	}
+}
+
+func TestPrivilegeKeyAdd(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"privilegekeyadd tokentype=0 tokenid1=7 tokenid2=0 tokendescription=Signup tokencustomset=ident=forum_id\\svalue=123\\pident=forum_user\\svalue=dante", "token=abc+def\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	token, err := ts3.PrivilegeKeyAdd(TokenTypeServerGroup, 7, 0, "Signup", map[string]string{"forum_user": "dante", "forum_id": "123"})
+	if err != nil {
+		t.Errorf("ts3.PrivilegeKeyAdd(): Errored out with %v", err)
+	} else if token != "abc+def" {
+		t.Errorf("ts3.PrivilegeKeyAdd(): Returned token %v", token)
+	}
+}
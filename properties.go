@@ -0,0 +1,214 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Splits a response containing several entries (separated by |) into the individual entries
+func splitEntries(response string) []string {
+	if len(response) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(response, "|")
+}
+
+// Fills in the sq tagged fields of v (a pointer to a struct) from a property string. Properties without a
+// matching field are skipped as the server adds new properties to its responses between releases.
+func decodeProperties(v interface{}, propertiesStr string) error {
+	return decodePropertiesWith(v, propertiesStr, false)
+}
+
+// Fills in the sq tagged fields of v from a property string, strict rejects properties without a matching field
+func decodePropertiesWith(v interface{}, propertiesStr string, strict bool) error {
+	reflected := reflect.ValueOf(v).Elem()
+	reflectedType := reflected.Type()
+
+	for _, token := range strings.Split(propertiesStr, " ") {
+		attribute := strings.SplitN(token, "=", 2)
+
+		// Properties without a value (e.g. "channel_topic") are left at their zero value
+		if len(attribute) != 2 {
+			continue
+		}
+
+		fieldFound := false
+		for i := 0; i < reflected.NumField(); i++ {
+			if reflectedType.Field(i).Tag.Get("sq") != attribute[0] {
+				continue
+			}
+			fieldFound = true
+
+			err := decodeValue(reflected.Field(i), attribute[1])
+			if err != nil {
+				return errors.New(fmt.Sprintf("Error parsing parameter (%v): %v", attribute[0], err))
+			}
+
+			break
+		}
+
+		if strict && !fieldFound {
+			return errors.New(fmt.Sprintf("Error invalid parameter detected (%v) from %v", attribute[0], propertiesStr))
+		}
+	}
+
+	return nil
+}
+
+// Parses a single escaped property value into the field
+func decodeValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+
+	case reflect.String:
+		field.SetString(Unescape(value))
+
+	default:
+		return errors.New(fmt.Sprintf("type %v not supported", field.Kind()))
+	}
+
+	return nil
+}
+
+// Builds the property string ("sq_tag=value ...") for the named fields of v (a pointer to a struct)
+func encodeProperties(v interface{}, fieldNames []string) (string, error) {
+	reflected := reflect.ValueOf(v).Elem()
+
+	if len(fieldNames) == 0 {
+		return "", errors.New("No fields listed")
+	}
+
+	properties := make([]string, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		fieldType, found := reflected.Type().FieldByName(fieldName)
+		if !found || fieldType.Tag.Get("sq") == "" {
+			return "", errors.New(fmt.Sprintf("Field %v not found on %v", fieldName, reflected.Type().Name()))
+		}
+
+		value, err := encodeValue(reflected.FieldByName(fieldName))
+		if err != nil {
+			return "", errors.New(fmt.Sprintf("Cannot handle valid parameter (%v): %v", fieldName, err))
+		}
+
+		properties[i] = fmt.Sprintf("%v=%v", fieldType.Tag.Get("sq"), value)
+	}
+
+	return strings.Join(properties, " "), nil
+}
+
+// Formats a single field as an escaped property value
+func encodeValue(field reflect.Value) (string, error) {
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64), nil
+
+	case reflect.Bool:
+		if field.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+
+	case reflect.String:
+		return Escape(field.String()), nil
+	}
+
+	return "", errors.New(fmt.Sprintf("type %v not supported", field.Kind()))
+}
+
+// Formats a bool as the 0/1 flag used by ServerQuery
+func boolFlag(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// Splits a comma separated list of field names, as accepted by Serialize
+func splitFields(fieldsStr string) []string {
+	if len(fieldsStr) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(fieldsStr, ",")
+}
+
+// Captures the encoded value of every sq tagged field of v (a pointer to a struct), keyed by field name
+func snapshotProperties(v interface{}) map[string]string {
+	reflected := reflect.ValueOf(v).Elem()
+	snapshot := make(map[string]string)
+
+	for i := 0; i < reflected.NumField(); i++ {
+		if reflected.Type().Field(i).Tag.Get("sq") == "" {
+			continue
+		}
+
+		value, err := encodeValue(reflected.Field(i))
+		if err == nil {
+			snapshot[reflected.Type().Field(i).Name] = value
+		}
+	}
+
+	return snapshot
+}
+
+// Lists the sq tagged fields of v (a pointer to a struct) whose value differs from the snapshot, fields missing
+// from the snapshot are compared against their zero value
+func changedFields(v interface{}, snapshot map[string]string) []string {
+	reflected := reflect.ValueOf(v).Elem()
+	changed := make([]string, 0)
+
+	for i := 0; i < reflected.NumField(); i++ {
+		fieldType := reflected.Type().Field(i)
+		if fieldType.Tag.Get("sq") == "" {
+			continue
+		}
+
+		original, found := snapshot[fieldType.Name]
+		if !found {
+			original, _ = encodeValue(reflect.Zero(fieldType.Type))
+		}
+
+		value, err := encodeValue(reflected.Field(i))
+		if err == nil && value != original {
+			changed = append(changed, fieldType.Name)
+		}
+	}
+
+	return changed
+}
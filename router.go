@@ -0,0 +1,243 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Handles a chat command, see Router
+type CommandHandler func(ctx *CommandContext) error
+
+// A chat command being dispatched along with who sent it and where
+type CommandContext struct {
+	Connection *Connection
+	Event      *TextMessageEvent
+	Name       string
+	Args       []string
+}
+
+// Id, unique id and nickname of the client that sent the command
+func (ctx *CommandContext) InvokerId() uint {
+	return ctx.Event.InvokerId
+}
+
+func (ctx *CommandContext) InvokerUid() string {
+	return ctx.Event.InvokerUid
+}
+
+func (ctx *CommandContext) InvokerName() string {
+	return ctx.Event.InvokerName
+}
+
+// Answers in the scope the command was sent in: privately to the invoker, to the channel or to the server
+func (ctx *CommandContext) Reply(message string) error {
+	target := TextMessageTarget{Mode: ctx.Event.TargetMode}
+	if ctx.Event.TargetMode == TextMessageTargetClient {
+		target.Target = ctx.Event.InvokerId
+	}
+
+	return ctx.Connection.SendTextMessage(target, message, true)
+}
+
+type routerCommand struct {
+	handler      CommandHandler
+	serverGroups []uint
+}
+
+// Dispatches chat commands (e.g. "!kick \"Some Name\" spamming") received as text messages to registered handlers
+type Router struct {
+	Prefix string
+
+	// Called with errors returned by handlers, when unset handler errors are dropped
+	OnError func(ctx *CommandContext, err error)
+
+	// Idle time after which Run sends a command so the server does not drop the query client for inactivity,
+	// defaults to DefaultKeepAlive
+	KeepAlive time.Duration
+
+	commands map[string]*routerCommand
+}
+
+// Default Router.KeepAlive, well inside the idle timeout after which the server disconnects query clients
+const DefaultKeepAlive = 3 * time.Minute
+
+// Creates a router for commands starting with prefix (e.g. "!")
+func NewRouter(prefix string) *Router {
+	return &Router{Prefix: prefix, commands: make(map[string]*routerCommand)}
+}
+
+// Registers the handler for the command name (without prefix). When server groups are listed only members of at
+// least one of them may run the command.
+func (router *Router) Handle(name string, handler CommandHandler, serverGroups ...uint) {
+	router.commands[strings.ToLower(name)] = &routerCommand{handler: handler, serverGroups: serverGroups}
+}
+
+// Registers for text messages in every scope and dispatches the commands received until the connection fails,
+// keeping an idle connection alive (see KeepAlive)
+func (router *Router) Run(ts3 *Connection) error {
+	keepAlive := router.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = DefaultKeepAlive
+	}
+
+	// Learn our own client id so our replies are not mistaken for commands
+	_, err := ts3.WhoAmI()
+	if err != nil {
+		return err
+	}
+
+	for _, event := range []string{NotifyEventTextPrivate, NotifyEventTextChannel, NotifyEventTextServer} {
+		err = ts3.ServerNotifyRegister(event, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		pending, err := ts3.waitNotification(keepAlive)
+		if err != nil {
+			return err
+		}
+
+		if !pending {
+			_, err = ts3.Version()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		notification, err := ts3.ReadNotification()
+		if err != nil {
+			return err
+		}
+
+		if notification.Type != "notifytextmessage" {
+			continue
+		}
+
+		event, err := notification.TextMessage()
+		if err != nil {
+			return err
+		}
+
+		err = router.Dispatch(ts3, event)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Runs the handler for the command in the text message, if any. Only errors talking to the server are returned,
+// handler errors go to OnError.
+func (router *Router) Dispatch(ts3 *Connection, event *TextMessageEvent) error {
+	if event.InvokerId == ts3.session.Clid || !strings.HasPrefix(event.Message, router.Prefix) {
+		return nil
+	}
+
+	name, args, err := parseCommandLine(strings.TrimPrefix(event.Message, router.Prefix))
+	if err != nil || len(name) == 0 {
+		return nil
+	}
+
+	command, found := router.commands[strings.ToLower(name)]
+	if !found {
+		return nil
+	}
+
+	ctx := &CommandContext{Connection: ts3, Event: event, Name: name, Args: args}
+
+	if len(command.serverGroups) > 0 {
+		allowed, err := router.allowed(ts3, event.InvokerId, command.serverGroups)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return ctx.Reply(fmt.Sprintf("You are not allowed to use %v%v", router.Prefix, name))
+		}
+	}
+
+	err = command.handler(ctx)
+	if err != nil && router.OnError != nil {
+		router.OnError(ctx, err)
+	}
+
+	return nil
+}
+
+// Reports whether the client is a member of any of the server groups
+func (router *Router) allowed(ts3 *Connection, clid uint, serverGroups []uint) (bool, error) {
+	memberOf, err := ts3.ClientServerGroups(clid)
+	if err != nil {
+		return false, err
+	}
+
+	for _, sgid := range memberOf {
+		for _, required := range serverGroups {
+			if sgid == required {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Splits a command line into the command name and its arguments. Arguments are separated by whitespace unless
+// enclosed in single or double quotes, a backslash escapes the next character.
+func parseCommandLine(line string) (string, []string, error) {
+	tokens := make([]string, 0)
+	var token strings.Builder
+	inToken := false
+	var quote rune
+	escaped := false
+
+	for _, char := range line {
+		switch {
+		case escaped:
+			token.WriteRune(char)
+			escaped = false
+
+		case char == '\\':
+			escaped = true
+			inToken = true
+
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				token.WriteRune(char)
+			}
+
+		case char == '"' || char == '\'':
+			quote = char
+			inToken = true
+
+		case char == ' ' || char == '\t' || char == '\n':
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+
+		default:
+			token.WriteRune(char)
+			inToken = true
+		}
+	}
+
+	if quote != 0 {
+		return "", nil, errors.New("Unterminated quote in command")
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	if len(tokens) == 0 {
+		return "", []string{}, nil
+	}
+
+	return tokens[0], tokens[1:], nil
+}
@@ -0,0 +1,98 @@
+package teamspeak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCommandLine(t *testing.T) {
+	name, args, err := parseCommandLine(`kick "Some Name" 'for spamming' it\'s`)
+	if err != nil {
+		t.Errorf("parseCommandLine(): Errored out with %v", err)
+	} else if name != "kick" || len(args) != 3 || args[0] != "Some Name" || args[1] != "for spamming" || args[2] != "it's" {
+		t.Errorf("parseCommandLine(): Parsed %v %q", name, args)
+	}
+
+	name, args, err = parseCommandLine(`  ping  `)
+	if err != nil || name != "ping" || len(args) != 0 {
+		t.Errorf("parseCommandLine(): Parsed %v %q (%v)", name, args, err)
+	}
+
+	if _, _, err = parseCommandLine(`say "unterminated`); err == nil {
+		t.Errorf("parseCommandLine(): Should have rejected an unterminated quote")
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"sendtextmessage targetmode=1 target=3 msg=pong\\shello\\sworld", "error id=0 msg=ok"},
+		{"clientinfo clid=3", "cid=1 client_servergroups=8\n\rerror id=0 msg=ok"},
+		{"sendtextmessage targetmode=2 target=0 msg=You\\sare\\snot\\sallowed\\sto\\suse\\s!kick", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+	ts3.session.Clid = 9
+
+	kicked := false
+	router := NewRouter("!")
+	router.Handle("ping", func(ctx *CommandContext) error {
+		return ctx.Reply("pong " + ctx.Args[0])
+	})
+	router.Handle("kick", func(ctx *CommandContext) error {
+		kicked = true
+		return nil
+	}, 6)
+
+	// Private command gets a private reply
+	err := router.Dispatch(ts3, &TextMessageEvent{TargetMode: TextMessageTargetClient, Message: "!PING \"hello world\"", InvokerId: 3})
+	if err != nil {
+		t.Errorf("router.Dispatch(!ping): Errored out with %v", err)
+	}
+
+	// Our own messages, chatter and unknown commands are ignored
+	for _, message := range []string{"!ping again", "hello", "!unknown"} {
+		invoker := uint(3)
+		if message == "!ping again" {
+			invoker = 9
+		}
+
+		err = router.Dispatch(ts3, &TextMessageEvent{TargetMode: TextMessageTargetChannel, Message: message, InvokerId: invoker})
+		if err != nil {
+			t.Errorf("router.Dispatch(%v): Errored out with %v", message, err)
+		}
+	}
+
+	// Restricted commands are refused in the channel they were sent to
+	err = router.Dispatch(ts3, &TextMessageEvent{TargetMode: TextMessageTargetChannel, Message: "!kick someone", InvokerId: 3})
+	if err != nil {
+		t.Errorf("router.Dispatch(!kick): Errored out with %v", err)
+	}
+	if kicked {
+		t.Errorf("router.Dispatch(!kick): Ran a command the invoker is not allowed to use")
+	}
+}
+
+func TestRouterKeepAlive(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"whoami", "virtualserver_status=online virtualserver_id=1 virtualserver_unique_identifier=abc= virtualserver_port=9987 client_id=9 client_channel_id=1 client_nickname=Bot client_database_id=1 client_login_name=serveradmin client_unique_identifier=serveradmin client_origin_server_id=0\n\rerror id=0 msg=ok"},
+		{"servernotifyregister event=textprivate", "error id=0 msg=ok"},
+		{"servernotifyregister event=textchannel", "error id=0 msg=ok"},
+		{"servernotifyregister event=textserver", "error id=0 msg=ok"},
+		{"version", "version=3.13.7 build=1655727713 platform=Linux\n\rerror id=0 msg=ok"},
+		{"version", "version=3.13.7 build=1655727713 platform=Linux\n\rerror id=0 msg=ok\n\rnotifytextmessage targetmode=1 msg=!ping target=9 invokerid=3 invokername=Alice invokeruid=abc="},
+	})
+	defer ts3.Close()
+
+	// With no messages arriving the router keeps the connection busy until the command sent after the second
+	// keepalive, it only returns once the stand-in server hangs up
+	pinged := false
+	router := NewRouter("!")
+	router.KeepAlive = 10 * time.Millisecond
+	router.Handle("ping", func(ctx *CommandContext) error {
+		pinged = true
+		return nil
+	})
+
+	if err := router.Run(ts3); err == nil || !pinged {
+		t.Errorf("router.Run(): Expected the command to be handled before the connection failed, received %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package teamspeak
+
+import (
+	"fmt"
+)
+
+type ServerGroup struct {
+	Sgid                    uint   `sq:"sgid"`
+	Name                    string `sq:"name"`
+	Type                    uint   `sq:"type"`
+	IconId                  int    `sq:"iconid"`
+	SaveDb                  bool   `sq:"savedb"`
+	SortId                  uint   `sq:"sortid"`
+	NameMode                uint   `sq:"namemode"`
+	NeededModifyPower       uint   `sq:"n_modifyp"`
+	NeededMemberAddPower    uint   `sq:"n_member_addp"`
+	NeededMemberRemovePower uint   `sq:"n_member_removep"`
+}
+
+// A client (by database id) that is a member of a server group
+type ServerGroupMember struct {
+	Cldbid   uint   `sq:"cldbid"`
+	Nickname string `sq:"client_nickname"`
+	Uid      string `sq:"client_unique_identifier"`
+}
+
+func NewServerGroup(serverGroupStr string) (*ServerGroup, error) {
+	serverGroup := &ServerGroup{}
+
+	err := decodeProperties(serverGroup, serverGroupStr)
+	if err != nil {
+		return serverGroup, err
+	}
+
+	return serverGroup, nil
+}
+
+// Parses the response of a command returning a list of server groups
+func parseServerGroups(response string) ([]*ServerGroup, error) {
+	rawServerGroups := splitEntries(response)
+	serverGroups := make([]*ServerGroup, len(rawServerGroups))
+
+	for i, rawServerGroup := range rawServerGroups {
+		serverGroup, err := NewServerGroup(rawServerGroup)
+		if err != nil {
+			return serverGroups, err
+		}
+		serverGroups[i] = serverGroup
+	}
+
+	return serverGroups, nil
+}
+
+// Reads the list of server groups on the selected virtual server
+func (ts3 *Connection) ServerGroupList() ([]*ServerGroup, error) {
+	response, err := ts3.exec("servergrouplist")
+	if err != nil {
+		return make([]*ServerGroup, 0), err
+	}
+
+	return parseServerGroups(response)
+}
+
+// Creates a new server group of the given type (see GroupType*), returning its id
+func (ts3 *Connection) ServerGroupAdd(name string, groupType uint) (uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("servergroupadd name=%v type=%d", Escape(name), groupType))
+	if err != nil {
+		return 0, err
+	}
+
+	created := struct {
+		Sgid uint `sq:"sgid"`
+	}{}
+	err = decodeProperties(&created, response)
+
+	return created.Sgid, err
+}
+
+// Deletes the server group, force deletes it even when clients are still members
+func (ts3 *Connection) ServerGroupDel(sgid uint, force bool) error {
+	_, err := ts3.exec(fmt.Sprintf("servergroupdel sgid=%d force=%d", sgid, boolFlag(force)))
+	return err
+}
+
+// Changes the name of the server group
+func (ts3 *Connection) ServerGroupRename(sgid uint, name string) error {
+	_, err := ts3.exec(fmt.Sprintf("servergrouprename sgid=%d name=%v", sgid, Escape(name)))
+	return err
+}
+
+// Copies the source server group onto the target group, a target of 0 creates a new group whose id is returned
+func (ts3 *Connection) ServerGroupCopy(ssgid, tsgid uint, name string, groupType uint) (uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("servergroupcopy ssgid=%d tsgid=%d name=%v type=%d", ssgid, tsgid, Escape(name), groupType))
+	if err != nil {
+		return 0, err
+	}
+
+	created := struct {
+		Sgid uint `sq:"sgid"`
+	}{tsgid}
+	err = decodeProperties(&created, response)
+
+	return created.Sgid, err
+}
+
+// Lists the members of the server group, names includes their nickname and unique id
+func (ts3 *Connection) ServerGroupClientList(sgid uint, names bool) ([]*ServerGroupMember, error) {
+	command := fmt.Sprintf("servergroupclientlist sgid=%d", sgid)
+	if names {
+		command += " -names"
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*ServerGroupMember, 0), nil
+	}
+	if err != nil {
+		return make([]*ServerGroupMember, 0), err
+	}
+
+	rawMembers := splitEntries(response)
+	members := make([]*ServerGroupMember, len(rawMembers))
+	for i, rawMember := range rawMembers {
+		members[i] = &ServerGroupMember{}
+		err = decodeProperties(members[i], rawMember)
+		if err != nil {
+			return members, err
+		}
+	}
+
+	return members, nil
+}
+
+// Adds the client (by database id) to the server group
+func (ts3 *Connection) ServerGroupAddClient(sgid, cldbid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("servergroupaddclient sgid=%d cldbid=%d", sgid, cldbid))
+	return err
+}
+
+// Removes the client (by database id) from the server group
+func (ts3 *Connection) ServerGroupDelClient(sgid, cldbid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("servergroupdelclient sgid=%d cldbid=%d", sgid, cldbid))
+	return err
+}
+
+// Lists the server groups the client (by database id) is a member of
+func (ts3 *Connection) ServerGroupsByClientId(cldbid uint) ([]*ServerGroup, error) {
+	response, err := ts3.exec(fmt.Sprintf("servergroupsbyclientid cldbid=%d", cldbid))
+	if isEmptyResult(err) {
+		return make([]*ServerGroup, 0), nil
+	}
+	if err != nil {
+		return make([]*ServerGroup, 0), err
+	}
+
+	return parseServerGroups(response)
+}
+
+// Lists the permissions assigned to the server group, permsid returns the permission names rather than ids
+func (ts3 *Connection) ServerGroupPermList(sgid uint, permsid bool) ([]*Permission, error) {
+	return ts3.permList(fmt.Sprintf("servergrouppermlist sgid=%d", sgid), permsid)
+}
+
+// Adds or updates one or more permissions on the server group
+func (ts3 *Connection) ServerGroupAddPerm(sgid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("servergroupaddperm sgid=%d", sgid), perms, permFull)
+}
+
+// Removes one or more permissions from the server group
+func (ts3 *Connection) ServerGroupDelPerm(sgid uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("servergroupdelperm sgid=%d", sgid), perms, permIdentifier)
+}
+
+// Adds or updates permissions on every regular server group of the type class (see ServerGroupType*) across all
+// virtual servers of the instance
+func (ts3 *Connection) ServerGroupAutoAddPerm(sgtype uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("servergroupautoaddperm sgtype=%d", sgtype), perms, permFull)
+}
+
+// Removes permissions from every regular server group of the type class (see ServerGroupType*) across all
+// virtual servers of the instance
+func (ts3 *Connection) ServerGroupAutoDelPerm(sgtype uint, perms []*Permission) error {
+	return ts3.permEdit(fmt.Sprintf("servergroupautodelperm sgtype=%d", sgtype), perms, permIdentifier)
+}
@@ -0,0 +1,32 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validServerGroupListString = "sgid=6 name=Server\\sAdmin type=1 iconid=300 savedb=1 sortid=0 namemode=0 n_modifyp=75 n_member_addp=75 n_member_removep=75|sgid=8 name=Guest type=1 iconid=0 savedb=0 sortid=0 namemode=0 n_modifyp=75 n_member_addp=0 n_member_removep=0"
+const validServerGroupsByClientIdString = "name=Server\\sAdmin sgid=6 cldbid=2"
+
+func TestParseServerGroups(t *testing.T) {
+	serverGroups, err := parseServerGroups(validServerGroupListString)
+	if err != nil {
+		t.Errorf("parseServerGroups(\"%v\"): Errored out with %v", validServerGroupListString, err)
+	} else if len(serverGroups) != 2 {
+		t.Errorf("parseServerGroups(\"%v\"): Expected 2 server groups, received %d", validServerGroupListString, len(serverGroups))
+	} else {
+		admin := serverGroups[0]
+		if admin.Sgid != 6 || admin.Name != "Server Admin" || admin.Type != GroupTypeRegular || admin.IconId != 300 || !admin.SaveDb || admin.NeededModifyPower != 75 {
+			t.Errorf("parseServerGroups(\"%v\"): Parsed version %v does not match source input", validServerGroupListString, admin)
+		}
+		if serverGroups[1].Sgid != 8 || serverGroups[1].Name != "Guest" || serverGroups[1].SaveDb {
+			t.Errorf("parseServerGroups(\"%v\"): Parsed version %v does not match source input", validServerGroupListString, serverGroups[1])
+		}
+	}
+
+	serverGroups, err = parseServerGroups(validServerGroupsByClientIdString)
+	if err != nil {
+		t.Errorf("parseServerGroups(\"%v\"): Errored out with %v", validServerGroupsByClientIdString, err)
+	} else if len(serverGroups) != 1 || serverGroups[0].Sgid != 6 || serverGroups[0].Name != "Server Admin" {
+		t.Errorf("parseServerGroups(\"%v\"): Parsed version %v does not match source input", validServerGroupsByClientIdString, serverGroups)
+	}
+}
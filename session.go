@@ -0,0 +1,92 @@
+package teamspeak
+
+import (
+	"errors"
+)
+
+// The identity of the query client and the server it has selected, as returned by whoami
+type WhoAmI struct {
+	ServerStatus           string `sq:"virtualserver_status"`
+	ServerId               uint   `sq:"virtualserver_id"`
+	ServerUniqueIdentifier string `sq:"virtualserver_unique_identifier"`
+	ServerPort             uint   `sq:"virtualserver_port"`
+	ClientId               uint   `sq:"client_id"`
+	ClientChannelId        uint   `sq:"client_channel_id"`
+	ClientNickname         string `sq:"client_nickname"`
+	ClientDatabaseId       uint   `sq:"client_database_id"`
+	ClientLoginName        string `sq:"client_login_name"`
+	ClientUniqueIdentifier string `sq:"client_unique_identifier"`
+	ClientOriginServerId   uint   `sq:"client_origin_server_id"`
+}
+
+// The state of the ServerQuery session as tracked locally by the Connection. Fields the library could not
+// determine without asking the server are left at their zero value, call WhoAmI to fill them in.
+type Session struct {
+	// Login name used to authenticate, empty when not logged in
+	Username string
+
+	// Selected virtual server, by id or (when selected with UsePort) by port
+	Sid  uint
+	Port uint
+
+	// Client id, nickname and channel of the query client on the selected server
+	Clid     uint
+	Nickname string
+	Cid      uint
+}
+
+// Reports whether the session has been authenticated
+func (session Session) LoggedIn() bool {
+	return len(session.Username) > 0
+}
+
+// Reports whether a virtual server has been selected
+func (session Session) ServerSelected() bool {
+	return session.Sid != 0 || session.Port != 0
+}
+
+// Returns the locally tracked session state
+func (ts3 *Connection) Session() Session {
+	return ts3.session
+}
+
+// Returns an error unless the session has been authenticated
+func (ts3 *Connection) RequireLogin() error {
+	if !ts3.session.LoggedIn() {
+		return errors.New("Not logged in")
+	}
+
+	return nil
+}
+
+// Returns an error unless a virtual server has been selected
+func (ts3 *Connection) RequireServer() error {
+	if !ts3.session.ServerSelected() {
+		return errors.New("No virtual server selected")
+	}
+
+	return nil
+}
+
+// Asks the server who the query client is and refreshes the locally tracked session with the answer
+func (ts3 *Connection) WhoAmI() (*WhoAmI, error) {
+	response, err := ts3.exec("whoami")
+	if err != nil {
+		return nil, err
+	}
+
+	whoAmI := &WhoAmI{}
+	err = decodeProperties(whoAmI, response)
+	if err != nil {
+		return whoAmI, err
+	}
+
+	ts3.session.Username = whoAmI.ClientLoginName
+	ts3.session.Sid = whoAmI.ServerId
+	ts3.session.Port = whoAmI.ServerPort
+	ts3.session.Clid = whoAmI.ClientId
+	ts3.session.Nickname = whoAmI.ClientNickname
+	ts3.session.Cid = whoAmI.ClientChannelId
+
+	return whoAmI, nil
+}
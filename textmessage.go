@@ -0,0 +1,122 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Who receives a text message, see PrivateTarget, ChannelTarget and ServerTarget
+type TextMessageTarget struct {
+	Mode   uint
+	Target uint
+}
+
+// Targets a private message at the client
+func PrivateTarget(clid uint) TextMessageTarget {
+	return TextMessageTarget{Mode: TextMessageTargetClient, Target: clid}
+}
+
+// Targets the channel the query client is currently in
+func ChannelTarget() TextMessageTarget {
+	return TextMessageTarget{Mode: TextMessageTargetChannel}
+}
+
+// Targets every client on the selected virtual server
+func ServerTarget() TextMessageTarget {
+	return TextMessageTarget{Mode: TextMessageTargetServer}
+}
+
+// Sends a text message to the target. Messages longer than MaxTextMessageLength are rejected unless split is set,
+// in which case they are sent as several messages broken up on line boundaries.
+func (ts3 *Connection) SendTextMessage(target TextMessageTarget, message string, split bool) error {
+	parts, err := messageParts(message, split)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		_, err = ts3.exec(fmt.Sprintf("sendtextmessage targetmode=%d target=%d msg=%v", target.Mode, target.Target, Escape(part)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sends a message to every client on every virtual server of the instance, split behaves as in SendTextMessage
+func (ts3 *Connection) GlobalMessage(message string, split bool) error {
+	parts, err := messageParts(message, split)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		_, err = ts3.exec(fmt.Sprintf("gm msg=%v", Escape(part)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Checks the message against the server limit, splitting it when allowed
+func messageParts(message string, split bool) ([]string, error) {
+	if len(message) <= MaxTextMessageLength {
+		return []string{message}, nil
+	}
+
+	if !split {
+		return nil, errors.New(fmt.Sprintf("Message of %d bytes exceeds the %d byte limit", len(message), MaxTextMessageLength))
+	}
+
+	return splitMessage(message, MaxTextMessageLength), nil
+}
+
+// Breaks the message into parts of at most limit bytes, preferring to break between lines. Lines that are longer
+// than the limit on their own are broken at the last character that fits.
+func splitMessage(message string, limit int) []string {
+	parts := make([]string, 0)
+	current := ""
+
+	for _, line := range strings.Split(message, "\n") {
+		// Break up lines that can never fit
+		for len(line) > limit {
+			if len(current) > 0 {
+				parts = append(parts, current)
+				current = ""
+			}
+
+			cut := limit
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+
+			// A limit shorter than the first character still has to make progress, send that character whole
+			if cut == 0 {
+				_, cut = utf8.DecodeRuneInString(line)
+			}
+			parts = append(parts, line[:cut])
+			line = line[cut:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = line
+		case len(current)+1+len(line) <= limit:
+			current += "\n" + line
+		default:
+			parts = append(parts, current)
+			current = line
+		}
+	}
+
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+
+	return parts
+}
@@ -0,0 +1,49 @@
+package teamspeak
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	// Lines are kept together while they fit
+	parts := splitMessage("one\ntwo\nthree", 7)
+	if len(parts) != 2 || parts[0] != "one\ntwo" || parts[1] != "three" {
+		t.Errorf("splitMessage(): Expected [one\\ntwo three], received %q", parts)
+	}
+
+	// Overlong lines are broken without splitting a multi-byte character
+	parts = splitMessage("abécd", 3)
+	if len(parts) != 3 || parts[0] != "ab" || parts[1] != "éc" || parts[2] != "d" {
+		t.Errorf("splitMessage(): Expected [ab éc d], received %q", parts)
+	}
+
+	// Characters longer than the limit are kept whole rather than looping forever
+	parts = splitMessage("éa", 1)
+	if len(parts) != 2 || parts[0] != "é" || parts[1] != "a" {
+		t.Errorf("splitMessage(): Expected [é a], received %q", parts)
+	}
+
+	long := strings.Repeat("x", MaxTextMessageLength+1)
+	if _, err := messageParts(long, false); err == nil {
+		t.Errorf("messageParts(): Should have rejected a message over the limit")
+	}
+	if parts, err := messageParts(long, true); err != nil || len(parts) != 2 {
+		t.Errorf("messageParts(): Expected 2 parts, received %d (%v)", len(parts), err)
+	}
+}
+
+func TestSendTextMessage(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"sendtextmessage targetmode=1 target=5 msg=Hello\\sthere\\p", "error id=0 msg=ok"},
+		{"gm msg=Restart\\sin\\s5\\sminutes", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	if err := ts3.SendTextMessage(PrivateTarget(5), "Hello there|", false); err != nil {
+		t.Errorf("ts3.SendTextMessage(): Errored out with %v", err)
+	}
+	if err := ts3.GlobalMessage("Restart in 5 minutes", false); err != nil {
+		t.Errorf("ts3.GlobalMessage(): Errored out with %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+package teamspeak
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// Options for Upload and Download
+type TransferOptions struct {
+	// Channel password, if the channel has one
+	Password string
+
+	// Replace an existing file when uploading
+	Overwrite bool
+
+	// Continue a previously interrupted upload, skipping the bytes the server already has
+	Resume bool
+
+	// Offset to start downloading from, to continue a previously interrupted download
+	SeekPos uint64
+
+	// Called as data flows with the bytes transferred so far (including any resumed offset) and the file size
+	Progress func(transferred, total uint64)
+
+	// Host to connect to for the data transfer, overrides Connection.TransferHost
+	Host string
+}
+
+// The reply to ftinitupload and ftinitdownload
+type fileTransferInit struct {
+	ClientFtfid uint   `sq:"clientftfid"`
+	ServerFtfid uint   `sq:"serverftfid"`
+	Key         string `sq:"ftkey"`
+	Port        uint   `sq:"port"`
+	SeekPos     uint64 `sq:"seekpos"`
+	Size        uint64 `sq:"size"`
+	Status      uint   `sq:"status"`
+	Msg         string `sq:"msg"`
+}
+
+// Uploads the contents of reader to the file (full path) in the channel's file area. The size of the upload is
+// taken from the reader when it is an io.Seeker, otherwise the reader is buffered in memory.
+func (ts3 *Connection) Upload(cid uint, name string, reader io.Reader, options ...TransferOptions) error {
+	option := transferOption(options)
+
+	size, reader, err := readerSize(reader)
+	if err != nil {
+		return err
+	}
+
+	ts3.ftfid++
+	init, err := ts3.initTransfer(fmt.Sprintf("ftinitupload clientftfid=%d name=%v cid=%d cpw=%v size=%d overwrite=%d resume=%d", ts3.ftfid, Escape(name), cid, Escape(option.Password), size, boolFlag(option.Overwrite), boolFlag(option.Resume)))
+	if err != nil {
+		return err
+	}
+
+	if init.SeekPos > size {
+		return errors.New(fmt.Sprintf("Server already has %d bytes of %v, more than the %d bytes being uploaded", init.SeekPos, name, size))
+	}
+
+	// Skip what the server already has when resuming
+	if init.SeekPos > 0 {
+		if seeker, ok := reader.(io.Seeker); ok {
+			_, err = seeker.Seek(int64(init.SeekPos), io.SeekCurrent)
+		} else {
+			_, err = io.CopyN(io.Discard, reader, int64(init.SeekPos))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := ts3.dialTransfer(init, option)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	progress := &progressReader{reader: reader, transferred: init.SeekPos, total: size, progress: option.Progress}
+	_, err = io.CopyN(conn, progress, int64(size-init.SeekPos))
+
+	return err
+}
+
+// Opens the file (full path) in the channel's file area for reading, the returned stream must be closed
+func (ts3 *Connection) Download(cid uint, name string, options ...TransferOptions) (io.ReadCloser, error) {
+	option := transferOption(options)
+
+	ts3.ftfid++
+	init, err := ts3.initTransfer(fmt.Sprintf("ftinitdownload clientftfid=%d name=%v cid=%d cpw=%v seekpos=%d", ts3.ftfid, Escape(name), cid, Escape(option.Password), option.SeekPos))
+	if err != nil {
+		return nil, err
+	}
+
+	if option.SeekPos > init.Size {
+		return nil, errors.New(fmt.Sprintf("Cannot resume %v at byte %d, the file is only %d bytes", name, option.SeekPos, init.Size))
+	}
+
+	conn, err := ts3.dialTransfer(init, option)
+	if err != nil {
+		return nil, err
+	}
+
+	return &download{
+		progressReader: progressReader{
+			reader:      &exactReader{reader: conn, remaining: int64(init.Size - option.SeekPos)},
+			transferred: option.SeekPos,
+			total:       init.Size,
+			progress:    option.Progress,
+		},
+		conn: conn,
+	}, nil
+}
+
+// Issues ftinitupload/ftinitdownload, turning a status reported in the reply into an error
+func (ts3 *Connection) initTransfer(command string) (*fileTransferInit, error) {
+	response, err := ts3.exec(command)
+	if err != nil {
+		return nil, err
+	}
+
+	init := &fileTransferInit{}
+	err = decodeProperties(init, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if init.Status != 0 {
+		return nil, &Error{Id: init.Status, Msg: init.Msg}
+	}
+
+	return init, nil
+}
+
+// Connects to the file transfer port and identifies the transfer with its key
+func (ts3 *Connection) dialTransfer(init *fileTransferInit, option TransferOptions) (net.Conn, error) {
+	host := option.Host
+	if len(host) == 0 {
+		host = ts3.TransferHost
+	}
+	if len(host) == 0 {
+		var err error
+
+		host, _, err = net.SplitHostPort(ts3.conn.RemoteAddr().String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(host, strconv.FormatUint(uint64(init.Port), 10)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.WriteString(conn, init.Key)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Returns the first of the optional transfer options
+func transferOption(options []TransferOptions) TransferOptions {
+	if len(options) > 0 {
+		return options[0]
+	}
+
+	return TransferOptions{}
+}
+
+// Determines how many bytes are left in reader, buffering it when it cannot seek
+func readerSize(reader io.Reader) (uint64, io.Reader, error) {
+	if seeker, ok := reader.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, reader, err
+		}
+
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, reader, err
+		}
+
+		_, err = seeker.Seek(current, io.SeekStart)
+		return uint64(end - current), reader, err
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, reader, err
+	}
+
+	return uint64(len(data)), bytes.NewReader(data), nil
+}
+
+// Reports the bytes read through it to a progress callback
+type progressReader struct {
+	reader      io.Reader
+	transferred uint64
+	total       uint64
+	progress    func(transferred, total uint64)
+}
+
+func (reader *progressReader) Read(p []byte) (int, error) {
+	n, err := reader.reader.Read(p)
+
+	if n > 0 {
+		reader.transferred += uint64(n)
+		if reader.progress != nil {
+			reader.progress(reader.transferred, reader.total)
+		}
+	}
+
+	return n, err
+}
+
+// Reads exactly remaining bytes, the stream ending any earlier is reported as io.ErrUnexpectedEOF rather than
+// passing off a truncated transfer as complete
+type exactReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (reader *exactReader) Read(p []byte) (int, error) {
+	if reader.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > reader.remaining {
+		p = p[:reader.remaining]
+	}
+
+	n, err := reader.reader.Read(p)
+	reader.remaining -= int64(n)
+
+	if err == io.EOF && reader.remaining > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+// The stream returned by Download, closing it closes the data connection
+type download struct {
+	progressReader
+	conn net.Conn
+}
+
+func (stream *download) Close() error {
+	return stream.conn.Close()
+}
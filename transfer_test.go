@@ -0,0 +1,134 @@
+package teamspeak
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+)
+
+// Accepts a single file transfer connection, checks the key and either stores what is sent or sends payload
+func serveTransfer(t *testing.T, key string, payload []byte) (uint, chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): Errored out with %v", err)
+	}
+
+	received := make(chan []byte, 1)
+	go func() {
+		defer listener.Close()
+
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+
+		sentKey := make([]byte, len(key))
+		if _, err = io.ReadFull(conn, sentKey); err != nil || string(sentKey) != key {
+			t.Errorf("Stand-in transfer server expected key %v, received %v", key, string(sentKey))
+		}
+
+		if payload != nil {
+			conn.Write(payload)
+			received <- nil
+			return
+		}
+
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+
+	return uint(listener.Addr().(*net.TCPAddr).Port), received
+}
+
+func TestUpload(t *testing.T) {
+	port, received := serveTransfer(t, "uploadkey", nil)
+	ts3 := newTestConnection(t, []testExchange{
+		{"ftinitupload clientftfid=1 name=\\/docs\\/rules.txt cid=5 cpw= size=11 overwrite=0 resume=1", fmt.Sprintf("clientftfid=1 serverftfid=3 ftkey=uploadkey port=%d seekpos=6\n\rerror id=0 msg=ok", port)},
+	})
+	defer ts3.Close()
+
+	// The server already has the first 6 bytes, only the rest is sent
+	progress := uint64(0)
+	err := ts3.Upload(5, "/docs/rules.txt", bytes.NewBufferString("hello world"), TransferOptions{
+		Resume:   true,
+		Host:     "127.0.0.1",
+		Progress: func(transferred, total uint64) { progress = transferred },
+	})
+	if err != nil {
+		t.Errorf("ts3.Upload(): Errored out with %v", err)
+	}
+
+	if data := <-received; string(data) != "world" {
+		t.Errorf("ts3.Upload(): Expected the server to receive \"world\", received %q", data)
+	}
+	if progress != 11 {
+		t.Errorf("ts3.Upload(): Expected progress to reach 11, reached %d", progress)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	port, done := serveTransfer(t, "downloadkey", []byte("file contents"))
+	ts3 := newTestConnection(t, []testExchange{
+		{"ftinitdownload clientftfid=1 name=\\/rules.txt cid=5 cpw=secret seekpos=0", fmt.Sprintf("clientftfid=1 serverftfid=4 ftkey=downloadkey port=%d size=13\n\rerror id=0 msg=ok", port)},
+		{"ftinitdownload clientftfid=2 name=\\/missing.txt cid=5 cpw= seekpos=0", "clientftfid=2 status=2051 msg=invalid\\sfile\\spath\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	stream, err := ts3.Download(5, "/rules.txt", TransferOptions{Password: "secret", Host: "127.0.0.1"})
+	if err != nil {
+		t.Errorf("ts3.Download(): Errored out with %v", err)
+	} else {
+		data, err := io.ReadAll(stream)
+		stream.Close()
+
+		if err != nil || string(data) != "file contents" {
+			t.Errorf("ts3.Download(): Expected \"file contents\", received %q (%v)", data, err)
+		}
+	}
+	<-done
+
+	if _, err = ts3.Download(5, "/missing.txt"); err == nil {
+		t.Errorf("ts3.Download(): Should have reported the status returned by the server")
+	}
+}
+
+func TestDownloadTruncated(t *testing.T) {
+	port, done := serveTransfer(t, "shortkey", []byte("file"))
+	ts3 := newTestConnection(t, []testExchange{
+		{"ftinitdownload clientftfid=1 name=\\/rules.txt cid=5 cpw= seekpos=0", fmt.Sprintf("clientftfid=1 serverftfid=4 ftkey=shortkey port=%d size=13\n\rerror id=0 msg=ok", port)},
+	})
+	defer ts3.Close()
+
+	// The server announced 13 bytes but closes the connection after 4
+	stream, err := ts3.Download(5, "/rules.txt", TransferOptions{Host: "127.0.0.1"})
+	if err != nil {
+		t.Errorf("ts3.Download(): Errored out with %v", err)
+		return
+	}
+	defer stream.Close()
+	<-done
+
+	if data, err := io.ReadAll(stream); err != io.ErrUnexpectedEOF {
+		t.Errorf("ts3.Download(): Expected io.ErrUnexpectedEOF for a truncated transfer, received %q (%v)", data, err)
+	}
+}
+
+func TestTransferOffsets(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"ftinitupload clientftfid=1 name=\\/small.txt cid=5 cpw= size=5 overwrite=0 resume=1", "clientftfid=1 serverftfid=3 ftkey=key port=1 seekpos=10\n\rerror id=0 msg=ok"},
+		{"ftinitdownload clientftfid=2 name=\\/small.txt cid=5 cpw= seekpos=10", "clientftfid=2 serverftfid=4 ftkey=key port=1 size=5\n\rerror id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	// Offsets past the end of the file are rejected before any data connection is made
+	if err := ts3.Upload(5, "/small.txt", bytes.NewBufferString("hello"), TransferOptions{Resume: true}); err == nil {
+		t.Errorf("ts3.Upload(): Should have rejected a seekpos past the end of the file")
+	}
+	if _, err := ts3.Download(5, "/small.txt", TransferOptions{SeekPos: 10}); err == nil {
+		t.Errorf("ts3.Download(): Should have rejected a seekpos past the end of the file")
+	}
+}
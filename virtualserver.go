@@ -0,0 +1,310 @@
+package teamspeak
+
+import (
+	"errors"
+	"fmt"
+)
+
+type VirtualServer struct {
+	// Retrieved in ServerList call
+	Id                 uint   `sq:"virtualserver_id"`
+	Port               uint   `sq:"virtualserver_port"`
+	Status             string `sq:"virtualserver_status"`
+	ClientsOnline      uint   `sq:"virtualserver_clientsonline"`
+	QueryClientsOnline uint   `sq:"virtualserver_queryclientsonline"`
+	MaxClients         uint   `sq:"virtualserver_maxclients"`
+	Uptime             int64  `sq:"virtualserver_uptime"`
+	Name               string `sq:"virtualserver_name"`
+	Autostart          bool   `sq:"virtualserver_autostart"`
+	MachineId          string `sq:"virtualserver_machine_id"`
+	UniqueIdentifier   string `sq:"virtualserver_unique_identifier"`
+
+	// Retrieved in ServerInfo call
+	WelcomeMessage                         string  `sq:"virtualserver_welcomemessage"`
+	Platform                               string  `sq:"virtualserver_platform"`
+	Version                                string  `sq:"virtualserver_version"`
+	Password                               string  `sq:"virtualserver_password"`
+	ChannelsOnline                         uint    `sq:"virtualserver_channelsonline"`
+	Created                                int64   `sq:"virtualserver_created"`
+	CodecEncryptionMode                    uint    `sq:"virtualserver_codec_encryption_mode"`
+	HostMessage                            string  `sq:"virtualserver_hostmessage"`
+	HostMessageMode                        uint    `sq:"virtualserver_hostmessage_mode"`
+	Filebase                               string  `sq:"virtualserver_filebase"`
+	DefaultServerGroup                     uint    `sq:"virtualserver_default_server_group"`
+	DefaultChannelGroup                    uint    `sq:"virtualserver_default_channel_group"`
+	DefaultChannelAdminGroup               uint    `sq:"virtualserver_default_channel_admin_group"`
+	FlagPassword                           bool    `sq:"virtualserver_flag_password"`
+	MaxDownloadTotalBandwidth              uint64  `sq:"virtualserver_max_download_total_bandwidth"`
+	MaxUploadTotalBandwidth                uint64  `sq:"virtualserver_max_upload_total_bandwidth"`
+	HostBannerUrl                          string  `sq:"virtualserver_hostbanner_url"`
+	HostBannerGfxUrl                       string  `sq:"virtualserver_hostbanner_gfx_url"`
+	HostBannerGfxInterval                  uint    `sq:"virtualserver_hostbanner_gfx_interval"`
+	HostBannerMode                         uint    `sq:"virtualserver_hostbanner_mode"`
+	HostButtonTooltip                      string  `sq:"virtualserver_hostbutton_tooltip"`
+	HostButtonUrl                          string  `sq:"virtualserver_hostbutton_url"`
+	HostButtonGfxUrl                       string  `sq:"virtualserver_hostbutton_gfx_url"`
+	ComplainAutobanCount                   uint    `sq:"virtualserver_complain_autoban_count"`
+	ComplainAutobanTime                    uint    `sq:"virtualserver_complain_autoban_time"`
+	ComplainRemoveTime                     uint    `sq:"virtualserver_complain_remove_time"`
+	MinClientsInChannelBeforeForcedSilence uint    `sq:"virtualserver_min_clients_in_channel_before_forced_silence"`
+	PrioritySpeakerDimmModificator         float64 `sq:"virtualserver_priority_speaker_dimm_modificator"`
+	AntifloodPointsTickReduce              uint    `sq:"virtualserver_antiflood_points_tick_reduce"`
+	AntifloodPointsNeededCommandBlock      uint    `sq:"virtualserver_antiflood_points_needed_command_block"`
+	AntifloodPointsNeededIpBlock           uint    `sq:"virtualserver_antiflood_points_needed_ip_block"`
+	ClientConnections                      uint64  `sq:"virtualserver_client_connections"`
+	QueryClientConnections                 uint64  `sq:"virtualserver_query_client_connections"`
+	DownloadQuota                          uint64  `sq:"virtualserver_download_quota"`
+	UploadQuota                            uint64  `sq:"virtualserver_upload_quota"`
+	MonthBytesDownloaded                   uint64  `sq:"virtualserver_month_bytes_downloaded"`
+	MonthBytesUploaded                     uint64  `sq:"virtualserver_month_bytes_uploaded"`
+	TotalBytesDownloaded                   uint64  `sq:"virtualserver_total_bytes_downloaded"`
+	TotalBytesUploaded                     uint64  `sq:"virtualserver_total_bytes_uploaded"`
+	NeededIdentitySecurityLevel            uint    `sq:"virtualserver_needed_identity_security_level"`
+	LogClient                              bool    `sq:"virtualserver_log_client"`
+	LogQuery                               bool    `sq:"virtualserver_log_query"`
+	LogChannel                             bool    `sq:"virtualserver_log_channel"`
+	LogPermissions                         bool    `sq:"virtualserver_log_permissions"`
+	LogServer                              bool    `sq:"virtualserver_log_server"`
+	LogFileTransfer                        bool    `sq:"virtualserver_log_filetransfer"`
+	MinClientVersion                       uint    `sq:"virtualserver_min_client_version"`
+	NamePhonetic                           string  `sq:"virtualserver_name_phonetic"`
+	IconId                                 int     `sq:"virtualserver_icon_id"`
+	ReservedSlots                          uint    `sq:"virtualserver_reserved_slots"`
+	TotalPacketlossSpeech                  float64 `sq:"virtualserver_total_packetloss_speech"`
+	TotalPacketlossKeepalive               float64 `sq:"virtualserver_total_packetloss_keepalive"`
+	TotalPacketlossControl                 float64 `sq:"virtualserver_total_packetloss_control"`
+	TotalPacketlossTotal                   float64 `sq:"virtualserver_total_packetloss_total"`
+	TotalPing                              float64 `sq:"virtualserver_total_ping"`
+	Ip                                     string  `sq:"virtualserver_ip"`
+	WeblistEnabled                         bool    `sq:"virtualserver_weblist_enabled"`
+	AskForPrivilegeKey                     bool    `sq:"virtualserver_ask_for_privilegekey"`
+	ChannelTempDeleteDelayDefault          uint    `sq:"virtualserver_channel_temp_delete_delay_default"`
+
+	// Values as last read from (or written to) the server, used to find the changed properties
+	snapshot map[string]string
+}
+
+// Fields reported by the server that cannot be changed through ServerEdit
+var readOnlyServerFields = map[string]bool{
+	"Id":                       true,
+	"Status":                   true,
+	"ClientsOnline":            true,
+	"QueryClientsOnline":       true,
+	"Uptime":                   true,
+	"MachineId":                true,
+	"UniqueIdentifier":         true,
+	"Platform":                 true,
+	"Version":                  true,
+	"ChannelsOnline":           true,
+	"Created":                  true,
+	"Filebase":                 true,
+	"ClientConnections":        true,
+	"QueryClientConnections":   true,
+	"MonthBytesDownloaded":     true,
+	"MonthBytesUploaded":       true,
+	"TotalBytesDownloaded":     true,
+	"TotalBytesUploaded":       true,
+	"TotalPacketlossSpeech":    true,
+	"TotalPacketlossKeepalive": true,
+	"TotalPacketlossControl":   true,
+	"TotalPacketlossTotal":     true,
+	"TotalPing":                true,
+	"FlagPassword":             true,
+}
+
+// Flags narrowing or widening the output of ServerList
+type ServerListOptions struct {
+	// Include the unique identifier of each server
+	Uid bool
+
+	// Include servers of every instance sharing the database
+	All bool
+
+	// Only return the basic properties of each server
+	Short bool
+
+	// Only return servers that are not running
+	OnlyOffline bool
+}
+
+// The identifiers of a freshly created virtual server, Token is the privilege key granting the initial server admin
+type CreatedServer struct {
+	Sid   uint   `sq:"sid"`
+	Port  uint   `sq:"virtualserver_port"`
+	Token string `sq:"token"`
+}
+
+func NewVirtualServer(serverStr string) (*VirtualServer, error) {
+	server := &VirtualServer{}
+
+	err := decodeProperties(server, serverStr)
+	if err != nil {
+		return server, err
+	}
+	server.snapshot = snapshotProperties(server)
+
+	return server, nil
+}
+
+// Lists the fields that were changed since the server was read
+func (server *VirtualServer) Changed() []string {
+	return changedFields(server, server.snapshot)
+}
+
+// Builds the property string for the changed fields, rejecting changes to read-only fields. An empty string
+// means there is nothing to save.
+func (server *VirtualServer) editProperties() (string, error) {
+	changed := server.Changed()
+	if len(changed) == 0 {
+		return "", nil
+	}
+
+	for _, fieldName := range changed {
+		if readOnlyServerFields[fieldName] {
+			return "", errors.New(fmt.Sprintf("Field %v is read-only on VirtualServer", fieldName))
+		}
+	}
+
+	return encodeProperties(server, changed)
+}
+
+// Reads the list of virtual servers on the instance
+func (ts3 *Connection) ServerList(options ServerListOptions) ([]*VirtualServer, error) {
+	command := "serverlist"
+	if options.Uid {
+		command += " -uid"
+	}
+	if options.All {
+		command += " -all"
+	}
+	if options.Short {
+		command += " -short"
+	}
+	if options.OnlyOffline {
+		command += " -onlyoffline"
+	}
+
+	response, err := ts3.exec(command)
+	if isEmptyResult(err) {
+		return make([]*VirtualServer, 0), nil
+	}
+	if err != nil {
+		return make([]*VirtualServer, 0), err
+	}
+
+	rawServers := splitEntries(response)
+	servers := make([]*VirtualServer, len(rawServers))
+	for i, rawServer := range rawServers {
+		server, err := NewVirtualServer(rawServer)
+		if err != nil {
+			return servers, err
+		}
+		servers[i] = server
+	}
+
+	return servers, nil
+}
+
+// Reads the properties of the selected virtual server
+func (ts3 *Connection) ServerInfo() (*VirtualServer, error) {
+	response, err := ts3.exec("serverinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVirtualServer(response)
+}
+
+// Creates a virtual server from the listed fields of server (Name is required), the new id and port are stored on server
+func (ts3 *Connection) ServerCreate(server *VirtualServer, fields string) (*CreatedServer, error) {
+	propertyString, err := encodeProperties(server, splitFields(fields))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ts3.exec(fmt.Sprintf("servercreate %v", propertyString))
+	if err != nil {
+		return nil, err
+	}
+
+	created := &CreatedServer{}
+	err = decodeProperties(created, response)
+	if err != nil {
+		return created, err
+	}
+
+	server.Id = created.Sid
+	server.Port = created.Port
+	server.snapshot = snapshotProperties(server)
+
+	return created, nil
+}
+
+// Deletes the virtual server, it must be stopped first
+func (ts3 *Connection) ServerDelete(sid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("serverdelete sid=%d", sid))
+	return err
+}
+
+// Starts the virtual server
+func (ts3 *Connection) ServerStart(sid uint) error {
+	_, err := ts3.exec(fmt.Sprintf("serverstart sid=%d", sid))
+	return err
+}
+
+// Stops the virtual server, the reason (if any) is shown to the connected clients
+func (ts3 *Connection) ServerStop(sid uint, reason string) error {
+	command := fmt.Sprintf("serverstop sid=%d", sid)
+	if len(reason) > 0 {
+		command += fmt.Sprintf(" reasonmsg=%v", Escape(reason))
+	}
+
+	_, err := ts3.exec(command)
+	return err
+}
+
+// Looks up the id of the virtual server listening on the UDP port
+func (ts3 *Connection) ServerIdGetByPort(port uint) (uint, error) {
+	response, err := ts3.exec(fmt.Sprintf("serveridgetbyport virtualserver_port=%d", port))
+	if err != nil {
+		return 0, err
+	}
+
+	found := struct {
+		Sid uint `sq:"server_id"`
+	}{}
+	err = decodeProperties(&found, response)
+
+	return found.Sid, err
+}
+
+// Saves the properties of server that changed since it was read. When server.Id is set it must be the selected
+// virtual server, the selection is left to the caller so the edit cannot land on another server.
+func (ts3 *Connection) ServerEdit(server *VirtualServer) error {
+	propertyString, err := server.editProperties()
+	if err != nil || len(propertyString) == 0 {
+		return err
+	}
+
+	if server.Id != 0 {
+		// Servers selected by port are only known by sid after asking
+		if ts3.session.Sid == 0 {
+			_, err = ts3.WhoAmI()
+			if err != nil {
+				return err
+			}
+		}
+
+		if ts3.session.Sid != server.Id {
+			return errors.New(fmt.Sprintf("Virtual server %d is not selected (selected %d), select it before editing", server.Id, ts3.session.Sid))
+		}
+	}
+
+	_, err = ts3.exec(fmt.Sprintf("serveredit %v", propertyString))
+	if err != nil {
+		return err
+	}
+
+	server.snapshot = snapshotProperties(server)
+	return nil
+}
@@ -0,0 +1,104 @@
+package teamspeak
+
+import (
+	"testing"
+)
+
+const validServerListString = "virtualserver_id=1 virtualserver_port=9987 virtualserver_status=online virtualserver_clientsonline=3 virtualserver_queryclientsonline=1 virtualserver_maxclients=32 virtualserver_uptime=3600 virtualserver_name=TeamSpeak\\s]I[\\sServer virtualserver_autostart=1 virtualserver_machine_id"
+const validServerInfoString = "virtualserver_unique_identifier=gNITtWtKs9+Uh3L4LKv8\\/YHsn5c= virtualserver_name=Test virtualserver_welcomemessage=Welcome\\sto\\sTeamSpeak virtualserver_download_quota=18446744073709551615 virtualserver_priority_speaker_dimm_modificator=-18.0000 virtualserver_total_ping=12.5 virtualserver_flag_password=0 virtualserver_some_future_property=1"
+
+func TestNewVirtualServer(t *testing.T) {
+	server, err := NewVirtualServer(validServerListString)
+	if err != nil {
+		t.Errorf("NewVirtualServer(\"%v\"): Errored out with %v", validServerListString, err)
+	} else {
+		if server.Id != 1 || server.Port != 9987 || server.Status != "online" || server.ClientsOnline != 3 || server.MaxClients != 32 || server.Uptime != 3600 || server.Name != "TeamSpeak ]I[ Server" || !server.Autostart || server.MachineId != "" {
+			t.Errorf("NewVirtualServer(\"%v\"): Parsed version %v does not match source input", validServerListString, server)
+		}
+	}
+
+	// Unknown properties are skipped, 64 bit counters and floats are parsed
+	server, err = NewVirtualServer(validServerInfoString)
+	if err != nil {
+		t.Errorf("NewVirtualServer(\"%v\"): Errored out with %v", validServerInfoString, err)
+	} else {
+		if server.UniqueIdentifier != "gNITtWtKs9+Uh3L4LKv8/YHsn5c=" || server.WelcomeMessage != "Welcome to TeamSpeak" || server.DownloadQuota != 18446744073709551615 || server.PrioritySpeakerDimmModificator != -18 || server.TotalPing != 12.5 || server.FlagPassword {
+			t.Errorf("NewVirtualServer(\"%v\"): Parsed version %v does not match source input", validServerInfoString, server)
+		}
+	}
+}
+
+func TestVirtualServerChanged(t *testing.T) {
+	server, err := NewVirtualServer(validServerListString)
+	if err != nil {
+		t.Errorf("NewVirtualServer(\"%v\"): Errored out with %v", validServerListString, err)
+		return
+	}
+
+	// Nothing changed, nothing to send
+	if changed := server.Changed(); len(changed) != 0 {
+		t.Errorf("server.Changed(): Expected no changes, received %v", changed)
+	}
+	if propertyString, err := server.editProperties(); err != nil || propertyString != "" {
+		t.Errorf("server.editProperties(): Expected nothing to send, received %v (%v)", propertyString, err)
+	}
+
+	// Only changed fields are sent
+	server.Name = "Renamed Server"
+	server.MaxClients = 64
+	propertyString, err := server.editProperties()
+	if err != nil {
+		t.Errorf("server.editProperties(): Errored out with %v", err)
+	} else if propertyString != "virtualserver_maxclients=64 virtualserver_name=Renamed\\sServer" {
+		t.Errorf("server.editProperties(): Returned %v", propertyString)
+	}
+
+	// Read-only fields are rejected
+	server.Uptime = 0
+	if _, err = server.editProperties(); err == nil {
+		t.Errorf("server.editProperties(): Should have rejected the change to Uptime")
+	}
+}
+
+func TestServerEdit(t *testing.T) {
+	ts3 := newTestConnection(t, []testExchange{
+		{"use sid=2 client_nickname=Bot", "error id=0 msg=ok"},
+		{"use sid=1 client_nickname=Bot", "error id=0 msg=ok"},
+		{"serveredit virtualserver_name=Renamed", "error id=0 msg=ok"},
+	})
+	defer ts3.Close()
+
+	if err := ts3.Use(2, UseOptions{Nickname: "Bot"}); err != nil {
+		t.Errorf("ts3.Use(2): Errored out with %v", err)
+	}
+
+	server, err := NewVirtualServer(validServerListString)
+	if err != nil {
+		t.Errorf("NewVirtualServer(\"%v\"): Errored out with %v", validServerListString, err)
+		return
+	}
+
+	// Unchanged servers are not sent at all
+	if err = ts3.ServerEdit(server); err != nil {
+		t.Errorf("ts3.ServerEdit(): Errored out with %v on an unchanged server", err)
+	}
+
+	// Editing a server other than the selected one is refused and leaves the caller's selection alone
+	server.Name = "Renamed"
+	if err = ts3.ServerEdit(server); err == nil {
+		t.Errorf("ts3.ServerEdit(): Should have thrown an error for a server that is not selected")
+	}
+	if session := ts3.Session(); session.Sid != 2 || session.Nickname != "Bot" || len(server.Changed()) != 1 {
+		t.Errorf("ts3.ServerEdit(): Changed the selection or dropped the changes, %v %v", session, server.Changed())
+	}
+
+	if err = ts3.Use(1, UseOptions{Nickname: "Bot"}); err != nil {
+		t.Errorf("ts3.Use(1): Errored out with %v", err)
+	}
+	if err = ts3.ServerEdit(server); err != nil {
+		t.Errorf("ts3.ServerEdit(): Errored out with %v", err)
+	}
+	if session := ts3.Session(); session.Sid != 1 || session.Nickname != "Bot" || len(server.Changed()) != 0 {
+		t.Errorf("ts3.ServerEdit(): Expected the selection kept and the changes saved, %v %v", session, server.Changed())
+	}
+}